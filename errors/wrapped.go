@@ -0,0 +1,139 @@
+package errors
+
+import (
+	stderrors "errors"
+	"fmt"
+	"io"
+)
+
+// Wrapped is the error given by Wrapper.Wrap. It has the same message as the
+// error it wraps with the prefix of the Wrapper prepended, but unlike an error
+// built from that message it keeps the wrapped error, so that it can still be
+// found by Is and As (such as when it is one of the error constants given by
+// ErrorString or New).
+type Wrapped struct {
+	Prefix string
+	Cause  error
+}
+
+// Error gives the prefix followed by the message of the wrapped error,
+// separated by a colon.
+func (w *Wrapped) Error() string {
+	if w.Cause == nil {
+		return w.Prefix
+	}
+
+	return w.Prefix + ": " + w.Cause.Error()
+}
+
+// Unwrap gives the wrapped error.
+func (w *Wrapped) Unwrap() error {
+	return w.Cause
+}
+
+// Is reports whether the target is a *Wrapped with the same prefix, wrapping an
+// error which matches (as by Is) the error wrapped by this one. Any other
+// target is instead compared with the wrapped error by Is as usual.
+func (w *Wrapped) Is(target error) bool {
+	t, ok := target.(*Wrapped)
+	if !ok || t == nil {
+		return false
+	}
+
+	return t.Prefix == w.Prefix && stderrors.Is(w.Cause, t.Cause)
+}
+
+// As sets the target to the Wrapper which gave this error if the target is a
+// *Wrapper, so that the prefix can be recovered. Since a Wrapper isn't an
+// error, only As in this package (and not the one in the standard errors
+// package) accepts a *Wrapper target. Any other target is instead checked
+// against the wrapped error by As as usual.
+func (w *Wrapped) As(target interface{}) bool {
+	if p, ok := target.(*Wrapper); ok {
+		*p = Wrapper(w.Prefix)
+		return true
+	}
+
+	return false
+}
+
+// Format allows Wrapped to implement fmt.Formatter. The %s and %v verbs give
+// the same message as Error, and %q gives it quoted. The %+v verb gives the
+// prefix and then each layer of the wrapped error on a line of its own, with
+// the wrapped error also formatted by %+v, and %#v gives a Go-syntax
+// representation.
+func (w *Wrapped) Format(s fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && s.Flag('+'):
+		_, _ = io.WriteString(s, w.Prefix)
+		if w.Cause != nil {
+			_, _ = fmt.Fprintf(s, "\ncaused by: %+v", w.Cause)
+		}
+	case verb == 'v' && s.Flag('#'):
+		_, _ = fmt.Fprintf(
+			s,
+			"&errors.Wrapped{Prefix:%q, Cause:%#v}",
+			w.Prefix,
+			w.Cause,
+		)
+	case verb == 'v' || verb == 's':
+		_, _ = io.WriteString(s, w.Error())
+	case verb == 'q':
+		_, _ = fmt.Fprintf(s, "%q", w.Error())
+	default:
+		_, _ = fmt.Fprintf(
+			s,
+			"%%!%c(*errors.Wrapped=%s)",
+			verb,
+			w.Error(),
+		)
+	}
+}
+
+// Is reports whether any error in the chain of err matches the target, just as
+// Is in the standard errors package does.
+func Is(err, target error) bool {
+	return stderrors.Is(err, target)
+}
+
+// As finds the first error in the chain of err which matches the target and
+// sets the target to it, just as As in the standard errors package does. The
+// target may also be a *Wrapper, in which case it is set to the Wrapper which
+// gave the first *Wrapped in the chain.
+func As(err error, target interface{}) bool {
+	if p, ok := target.(*Wrapper); ok {
+		return asWrapper(err, p)
+	}
+
+	return stderrors.As(err, target)
+}
+
+func asWrapper(err error, p *Wrapper) bool {
+	switch e := err.(type) {
+	case nil:
+		return false
+	case interface{ As(interface{}) bool }:
+		if e.As(p) {
+			return true
+		}
+	}
+
+	switch u := err.(type) {
+	case interface{ Unwrap() error }:
+		return asWrapper(u.Unwrap(), p)
+	case interface{ Unwrap() []error }:
+		for _, e := range u.Unwrap() {
+			if asWrapper(e, p) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Unwrap gives the error wrapped by err, just as Unwrap in the standard errors
+// package does.
+func Unwrap(err error) error {
+	return stderrors.Unwrap(err)
+}
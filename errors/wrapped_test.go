@@ -0,0 +1,203 @@
+package errors
+
+import (
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const wrappedConst = ErrorString("wrapped constant")
+
+func TestWrappedError(t *testing.T) {
+	type testCase struct {
+		inputWrapper Wrapper
+		inputError   error
+	}
+
+	tests := map[string]testCase{
+		"error constant": {
+			inputWrapper: Wrapper("prefix"),
+			inputError:   wrappedConst,
+		},
+		"appended prefix": {
+			inputWrapper: Wrapper("a").AppendedWith("b"),
+			inputError:   New("cause"),
+		},
+		"standard error": {
+			inputWrapper: Wrapper("prefix"),
+			inputError:   fmt.Errorf("formatted %d", 42),
+		},
+		"already wrapped": {
+			inputWrapper: Wrapper("outer"),
+			inputError:   Wrapper("inner").Wrap(wrappedConst),
+		},
+	}
+
+	for explanation, test := range tests {
+		actual := test.inputWrapper.Wrap(test.inputError)
+
+		assert.Equal(
+			t,
+			fmt.Sprintf(
+				"%s: %s",
+				string(test.inputWrapper),
+				test.inputError.Error(),
+			),
+			actual.Error(),
+			"Wrapped test expects the same message as before"+
+				" for: %s",
+			explanation,
+		)
+		assert.True(
+			t,
+			Is(actual, test.inputError),
+			"Wrapped test expects Is to find the cause for: %s",
+			explanation,
+		)
+		assert.Equal(
+			t,
+			test.inputError,
+			Unwrap(actual),
+			"Wrapped test expects Unwrap to give the cause for: %s",
+			explanation,
+		)
+
+		var w Wrapper
+		assert.True(
+			t,
+			As(actual, &w),
+			"Wrapped test expects As to find a Wrapper for: %s",
+			explanation,
+		)
+		assert.Equal(
+			t,
+			test.inputWrapper,
+			w,
+			"Wrapped test expects As to give the Wrapper for: %s",
+			explanation,
+		)
+	}
+}
+
+func TestWrappedIs(t *testing.T) {
+	type testCase struct {
+		inputError  error
+		inputTarget error
+		expected    bool
+	}
+
+	w := Wrapper("prefix")
+	inner := w.AppendedWith("b").Wrap(wrappedConst)
+	tests := map[string]testCase{
+		"constant": {
+			inputError:  w.Wrap(wrappedConst),
+			inputTarget: wrappedConst,
+			expected:    true,
+		},
+		"deeply wrapped constant": {
+			inputError:  w.Wrap(inner),
+			inputTarget: wrappedConst,
+			expected:    true,
+		},
+		"different constant": {
+			inputError:  w.Wrap(wrappedConst),
+			inputTarget: ErrorString("another constant"),
+			expected:    false,
+		},
+		"equivalent wrap": {
+			inputError:  w.Wrap(wrappedConst),
+			inputTarget: w.Wrap(wrappedConst),
+			expected:    true,
+		},
+		"different prefix": {
+			inputError:  w.Wrap(wrappedConst),
+			inputTarget: Wrapper("other").Wrap(wrappedConst),
+			expected:    false,
+		},
+		"different cause": {
+			inputError:  w.Wrap(wrappedConst),
+			inputTarget: w.Wrap(io.EOF),
+			expected:    false,
+		},
+		"standard error": {
+			inputError:  w.Wrap(fmt.Errorf("outer: %w", io.EOF)),
+			inputTarget: io.EOF,
+			expected:    true,
+		},
+	}
+
+	for explanation, test := range tests {
+		assert.Equal(
+			t,
+			test.expected,
+			Is(test.inputError, test.inputTarget),
+			"Wrapped Is test expects a different result for: %s",
+			explanation,
+		)
+	}
+}
+
+func TestWrappedFormat(t *testing.T) {
+	type testCase struct {
+		inputFormat string
+		inputError  error
+		expected    string
+	}
+
+	w := Wrapper("prefix")
+	inner := w.AppendedWith("b").Wrap(wrappedConst)
+	tests := map[string]testCase{
+		"v": {
+			inputFormat: "%v",
+			inputError:  w.Wrap(wrappedConst),
+			expected:    "prefix: wrapped constant",
+		},
+		"s": {
+			inputFormat: "%s",
+			inputError:  w.Wrap(wrappedConst),
+			expected:    "prefix: wrapped constant",
+		},
+		"q": {
+			inputFormat: "%q",
+			inputError:  w.Wrap(wrappedConst),
+			expected:    `"prefix: wrapped constant"`,
+		},
+		"plus v": {
+			inputFormat: "%+v",
+			inputError:  w.Wrap(inner),
+			expected: "prefix\n" +
+				"caused by: prefix: b\n" +
+				"caused by: wrapped constant",
+		},
+		"sharp v": {
+			inputFormat: "%#v",
+			inputError:  w.Wrap(New("cause")),
+			expected: `&errors.Wrapped{Prefix:"prefix",` +
+				` Cause:"cause"}`,
+		},
+		"bad verb": {
+			inputFormat: "%d",
+			inputError:  w.Wrap(wrappedConst),
+			expected: "%!d(*errors.Wrapped=prefix:" +
+				" wrapped constant)",
+		},
+		"nil cause": {
+			inputFormat: "%+v",
+			inputError:  &Wrapped{Prefix: "prefix"},
+			expected:    "prefix",
+		},
+	}
+
+	for explanation, test := range tests {
+		assert.Equal(
+			t,
+			test.expected,
+			fmt.Sprintf(test.inputFormat, test.inputError),
+			"Wrapped format test expects a different result"+
+				" for: %s",
+			explanation,
+		)
+	}
+}
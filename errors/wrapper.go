@@ -1,7 +1,9 @@
 package errors
 
 import (
+	stderrors "errors"
 	"fmt"
+	"strings"
 )
 
 // Wrapper provides a convenient mechanism for wrapping a number of errors
@@ -14,11 +16,73 @@ func (w Wrapper) AppendedWith(s string) Wrapper {
 	return Wrapper(fmt.Sprintf("%s: %s", string(w), s))
 }
 
-// Wrap prepends the previously set prefix to the given non-nil error. It will
-// refrain from wrapping a nil error.
+// Wrap prepends the previously set prefix to the given non-nil error, giving a
+// *Wrapped which keeps the original error so that it can still be found by Is
+// and As. It will refrain from wrapping a nil error.
 func (w Wrapper) Wrap(err error) error {
 	if nil != err {
-		return fmt.Errorf("%s: %s", string(w), err.Error())
+		return &Wrapped{
+			Prefix: string(w),
+			Cause:  err,
+		}
 	}
 	return nil
 }
+
+// Wrapf prepends the previously set prefix and then the message formatted as by
+// fmt.Sprintf to the given non-nil error, giving a *Wrapped just as Wrap does.
+// It will refrain from wrapping a nil error.
+func (w Wrapper) Wrapf(err error, format string, a ...interface{}) error {
+	if nil != err {
+		return w.AppendedWith(fmt.Sprintf(format, a...)).Wrap(err)
+	}
+	return nil
+}
+
+// WrapAll wraps each of the given non-nil errors as Wrap does, and gives an
+// error joining them (with a message that puts each of them on its own line)
+// which can be unwrapped to find any of them. It gives nil if none of the
+// errors are non-nil.
+func (w Wrapper) WrapAll(errs ...error) error {
+	var wrapped []error
+	for _, err := range errs {
+		if nil != err {
+			wrapped = append(wrapped, w.Wrap(err))
+		}
+	}
+
+	return stderrors.Join(wrapped...)
+}
+
+// New gives a new error with the given message, with the previously set prefix
+// prepended. The error wraps New(msg), so it matches any error given by New
+// with the same message by Is (but not an ErrorString with that message).
+func (w Wrapper) New(msg string) error {
+	return w.Wrap(New(msg))
+}
+
+// Annotate wraps the error pointed to as Wrap does, and is meant to be deferred
+// in a function with a named error result so that every error it returns is
+// given the prefix:
+//
+//	func load(name string) (err error) {
+//		defer Wrapper("Unable to load " + name).Annotate(&err)
+//		...
+//	}
+//
+// An error which was already given the prefix (such as by Wrap, Wrapf, or New
+// from the same Wrapper) isn't given it again, and a nil error is left alone.
+func (w Wrapper) Annotate(err *error) {
+	if err == nil || *err == nil {
+		return
+	}
+
+	if p, ok := (*err).(*Wrapped); ok {
+		if p.Prefix == string(w) ||
+			strings.HasPrefix(p.Prefix, string(w)+": ") {
+			return
+		}
+	}
+
+	*err = w.Wrap(*err)
+}
@@ -2,6 +2,10 @@ package errors
 
 import (
 	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func ExampleWrapper() {
@@ -27,3 +31,247 @@ func ExampleWrapper() {
 	// err2 is = <nil>
 	// err3 is = Error in wrapper test: Yet another error
 }
+
+func ExampleWrapper_Annotate() {
+	load := func(name string) (err error) {
+		defer Wrapper("Unable to load " + name).Annotate(&err)
+
+		if name == "" {
+			return New("No name was given")
+		}
+
+		return nil
+	}
+
+	fmt.Println(load(""))
+	fmt.Println(load("config"))
+	// Output:
+	// Unable to load : No name was given
+	// <nil>
+}
+
+func TestWrapperWrapf(t *testing.T) {
+	type testCase struct {
+		inputError    error
+		expected      string
+		expectedIsNil bool
+	}
+
+	tests := map[string]testCase{
+		"formatted": {
+			inputError: io.EOF,
+			expected:   "prefix: reading 42 bytes: EOF",
+		},
+		"nil": {
+			expectedIsNil: true,
+		},
+	}
+
+	for explanation, test := range tests {
+		actual := Wrapper("prefix").Wrapf(
+			test.inputError,
+			"reading %d bytes",
+			42,
+		)
+
+		if test.expectedIsNil {
+			assert.NoError(
+				t,
+				actual,
+				"Wrapf test expects nil for: %s",
+				explanation,
+			)
+			continue
+		}
+
+		assert.EqualError(
+			t,
+			actual,
+			test.expected,
+			"Wrapf test expects a different message for: %s",
+			explanation,
+		)
+		assert.True(
+			t,
+			Is(actual, test.inputError),
+			"Wrapf test expects Is to find the cause for: %s",
+			explanation,
+		)
+	}
+}
+
+func TestWrapperWrapAll(t *testing.T) {
+	type testCase struct {
+		inputErrors   []error
+		expected      string
+		expectedIsNil bool
+	}
+
+	tests := map[string]testCase{
+		"several errors": {
+			inputErrors: []error{io.EOF, nil, wrappedConst},
+			expected:    "prefix: EOF\nprefix: wrapped constant",
+		},
+		"single error": {
+			inputErrors: []error{nil, io.EOF},
+			expected:    "prefix: EOF",
+		},
+		"only nil errors": {
+			inputErrors:   []error{nil, nil},
+			expectedIsNil: true,
+		},
+		"no errors": {
+			expectedIsNil: true,
+		},
+	}
+
+	for explanation, test := range tests {
+		actual := Wrapper("prefix").WrapAll(test.inputErrors...)
+
+		if test.expectedIsNil {
+			assert.NoError(
+				t,
+				actual,
+				"WrapAll test expects nil for: %s",
+				explanation,
+			)
+			continue
+		}
+
+		assert.EqualError(
+			t,
+			actual,
+			test.expected,
+			"WrapAll test expects a different message for: %s",
+			explanation,
+		)
+
+		for _, e := range test.inputErrors {
+			if e != nil {
+				assert.True(
+					t,
+					Is(actual, e),
+					"WrapAll test expects Is to find each"+
+						" error for: %s",
+					explanation,
+				)
+			}
+		}
+
+		var w Wrapper
+		assert.True(
+			t,
+			As(actual, &w),
+			"WrapAll test expects As to find the Wrapper for: %s",
+			explanation,
+		)
+		assert.Equal(
+			t,
+			Wrapper("prefix"),
+			w,
+			"WrapAll test expects As to give the Wrapper for: %s",
+			explanation,
+		)
+	}
+}
+
+func TestWrapperNew(t *testing.T) {
+	actual := Wrapper("prefix").New("message")
+
+	assert.EqualError(
+		t,
+		actual,
+		"prefix: message",
+		"Wrapper New test expects a prefixed message.",
+	)
+	assert.True(
+		t,
+		Is(actual, New("message")),
+		"Wrapper New test expects Is to find an equal New.",
+	)
+	assert.False(
+		t,
+		Is(actual, ErrorString("message")),
+		"Wrapper New test expects a New not to match an ErrorString"+
+			" of a different type.",
+	)
+}
+
+func TestWrapperAnnotate(t *testing.T) {
+	type testCase struct {
+		inputError    error
+		expected      string
+		expectedIsNil bool
+	}
+
+	w := Wrapper("prefix")
+	tests := map[string]testCase{
+		"plain error": {
+			inputError: io.EOF,
+			expected:   "prefix: EOF",
+		},
+		"nil": {
+			expectedIsNil: true,
+		},
+		"already wrapped": {
+			inputError: w.Wrap(io.EOF),
+			expected:   "prefix: EOF",
+		},
+		"already wrapped with appended prefix": {
+			inputError: w.Wrapf(io.EOF, "reading"),
+			expected:   "prefix: reading: EOF",
+		},
+		"from New": {
+			inputError: w.New("message"),
+			expected:   "prefix: message",
+		},
+		"wrapped by a similar prefix": {
+			inputError: Wrapper("prefixed").Wrap(io.EOF),
+			expected:   "prefix: prefixed: EOF",
+		},
+		"wrapped by another Wrapper": {
+			inputError: Wrapper("other").Wrap(io.EOF),
+			expected:   "prefix: other: EOF",
+		},
+	}
+
+	for explanation, test := range tests {
+		actual := func() (err error) {
+			defer w.Annotate(&err)
+			return test.inputError
+		}()
+
+		if test.expectedIsNil {
+			assert.NoError(
+				t,
+				actual,
+				"Annotate test expects nil for: %s",
+				explanation,
+			)
+			continue
+		}
+
+		assert.EqualError(
+			t,
+			actual,
+			test.expected,
+			"Annotate test expects a different message for: %s",
+			explanation,
+		)
+		assert.True(
+			t,
+			Is(actual, test.inputError),
+			"Annotate test expects Is to find the original error"+
+				" for: %s",
+			explanation,
+		)
+	}
+
+	assert.NotPanics(
+		t,
+		func() {
+			w.Annotate(nil)
+		},
+		"Annotate test expects a nil pointer to be ignored.",
+	)
+}
@@ -0,0 +1,259 @@
+package log
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/proidiot/gone/log/mask"
+	"github.com/proidiot/gone/log/opt"
+	"github.com/proidiot/gone/log/pri"
+)
+
+// DefaultAdminRevert is the length of time after which a log mask set through
+// an Admin which has not been given a revert duration is reverted.
+const DefaultAdminRevert = 15 * time.Minute
+
+// counts holds the number of messages given to Syslog which were logged and
+// which failed, for each severity.
+var counts [8]struct {
+	logged uint64
+	failed uint64
+}
+
+func count(p pri.Priority, e error) {
+	c := &counts[p.Severity()]
+	if e == nil {
+		atomic.AddUint64(&c.logged, 1)
+	} else {
+		atomic.AddUint64(&c.failed, 1)
+	}
+}
+
+// Admin is an http.Handler which allows the global syslogger.Syslogger to be
+// inspected and adjusted on a live process.
+//
+// A GET request gives a JSON description of the global syslogger.Syslogger
+// along with the number of messages of each severity which have been logged
+// or have failed. If the global syslogger.Syslogger is a Posixish (or anything
+// else with Ident, Options, Facility, and LogMask functions), then these are
+// described as well.
+//
+// A PUT request sets the log mask of the global syslogger.Syslogger (which must
+// have a SetLogMask function) to the mask in the request body, such as
+// "LOG_UPTO(LOG_DEBUG)". The previous log mask is restored automatically after
+// the duration given by the revert query parameter (such as "?revert=1h"), or
+// else the Admin Revert. Further PUT requests before then restart the timer,
+// but the mask which will be restored stays the same. If the global
+// syslogger.Syslogger has a SetLogMaskFor function (as Posixish does), then it
+// is used so that the syslogger.Syslogger handles the revert itself.
+type Admin struct {
+	// Revert is the length of time after which a log mask set by a PUT
+	// request is reverted, and zero means DefaultAdminRevert.
+	Revert time.Duration
+
+	prior    mask.Mask
+	timer    *time.Timer
+	revertAt time.Time
+	gen      int
+	x        sync.Mutex
+}
+
+type adminState struct {
+	Syslogger string                  `json:"syslogger"`
+	Ident     *string                 `json:"ident,omitempty"`
+	Options   string                  `json:"options,omitempty"`
+	Facility  string                  `json:"facility,omitempty"`
+	Mask      string                  `json:"mask,omitempty"`
+	Revert    *adminRevert            `json:"revert,omitempty"`
+	Counters  map[string]adminCounter `json:"counters"`
+}
+
+type adminRevert struct {
+	Mask string    `json:"mask"`
+	At   time.Time `json:"at"`
+}
+
+type adminCounter struct {
+	Logged uint64 `json:"logged"`
+	Failed uint64 `json:"failed"`
+}
+
+type describer interface {
+	Ident() string
+	Options() opt.Option
+	Facility() pri.Priority
+	LogMask() mask.Mask
+}
+
+type logMasker interface {
+	SetLogMask(mask.Mask) mask.Mask
+	LogMask() mask.Mask
+}
+
+type timedMasker interface {
+	SetLogMaskFor(mask.Mask, time.Duration) error
+}
+
+// ServeHTTP handles a request to inspect or adjust the global
+// syslogger.Syslogger.
+func (a *Admin) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+	case http.MethodPut:
+		if code, e := a.put(r); e != nil {
+			http.Error(w, e.Error(), code)
+			return
+		}
+	default:
+		w.Header().Set("Allow", "GET, HEAD, PUT")
+		http.Error(
+			w,
+			http.StatusText(http.StatusMethodNotAllowed),
+			http.StatusMethodNotAllowed,
+		)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if r.Method == http.MethodHead {
+		return
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "\t")
+	_ = enc.Encode(a.state())
+}
+
+func (a *Admin) put(r *http.Request) (int, error) {
+	body, e := ioutil.ReadAll(r.Body)
+	if e != nil {
+		return http.StatusBadRequest, e
+	}
+
+	m, e := mask.Parse(string(body))
+	if e != nil {
+		return http.StatusBadRequest, e
+	}
+
+	d := a.Revert
+	if v := r.URL.Query().Get("revert"); v != "" {
+		d, e = time.ParseDuration(v)
+		if e != nil || d <= 0 {
+			return http.StatusBadRequest, fmt.Errorf(
+				"The revert parameter must be a positive"+
+					" duration such as 15m, but %q was"+
+					" given.",
+				v,
+			)
+		}
+	}
+	if d <= 0 {
+		d = DefaultAdminRevert
+	}
+
+	g := acquire()
+	defer g.release()
+
+	lm, ok := g.s.(logMasker)
+	if !ok {
+		return http.StatusConflict, fmt.Errorf(
+			"The global syslogger.Syslogger must have a"+
+				" SetLogMask function in order for its log"+
+				" mask to be set, but it is a %T.",
+			g.s,
+		)
+	}
+
+	a.x.Lock()
+	defer a.x.Unlock()
+
+	prior := lm.LogMask()
+	if time.Now().Before(a.revertAt) {
+		// A revert is still pending, so keep the original mask.
+		prior = a.prior
+	}
+
+	if a.timer != nil {
+		a.timer.Stop()
+		a.timer = nil
+	}
+
+	if tm, ok := g.s.(timedMasker); ok {
+		// The syslogger.Syslogger reverts the mask (and notes the
+		// changes) itself.
+		if e := tm.SetLogMaskFor(m, d); e != nil {
+			return http.StatusInternalServerError, e
+		}
+
+		a.prior = prior
+		a.revertAt = time.Now().Add(d)
+		return http.StatusOK, nil
+	}
+
+	lm.SetLogMask(m)
+
+	// The generation prevents a timer which fired while this request held
+	// the lock from reverting the new mask early.
+	a.gen++
+	gen := a.gen
+
+	a.prior = prior
+	a.revertAt = time.Now().Add(d)
+	a.timer = time.AfterFunc(d, func() {
+		a.x.Lock()
+		defer a.x.Unlock()
+		if a.gen != gen {
+			return
+		}
+
+		a.timer = nil
+		a.revertAt = time.Time{}
+		lm.SetLogMask(prior)
+	})
+
+	return http.StatusOK, nil
+}
+
+func (a *Admin) state() adminState {
+	g := acquire()
+	defer g.release()
+
+	st := adminState{
+		Syslogger: fmt.Sprintf("%T", g.s),
+		Counters:  map[string]adminCounter{},
+	}
+
+	if d, ok := g.s.(describer); ok {
+		ident := d.Ident()
+		st.Ident = &ident
+		st.Options = d.Options().String()
+		st.Facility = d.Facility().String()
+		st.Mask = d.LogMask().String()
+	} else if lm, ok := g.s.(logMasker); ok {
+		st.Mask = lm.LogMask().String()
+	}
+
+	a.x.Lock()
+	if time.Now().Before(a.revertAt) {
+		st.Revert = &adminRevert{
+			Mask: a.prior.String(),
+			At:   a.revertAt,
+		}
+	}
+	a.x.Unlock()
+
+	for sev := range counts {
+		c := &counts[sev]
+		st.Counters[pri.Priority(sev).String()] = adminCounter{
+			Logged: atomic.LoadUint64(&c.logged),
+			Failed: atomic.LoadUint64(&c.failed),
+		}
+	}
+
+	return st
+}
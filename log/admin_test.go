@@ -0,0 +1,276 @@
+package log
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/proidiot/gone/log/mask"
+	"github.com/proidiot/gone/log/pri"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdminServeHTTP(t *testing.T) {
+	type testCase struct {
+		inputMethod       string
+		inputTarget       string
+		inputBody         string
+		useLimited        bool
+		expectedCode      int
+		expectedMask      string
+		expectedRevert    bool
+		expectedDurations []time.Duration
+	}
+
+	tests := map[string]testCase{
+		"get": {
+			inputMethod:  http.MethodGet,
+			expectedCode: http.StatusOK,
+			expectedMask: "LOG_UPTO(LOG_INFO)",
+		},
+		"put": {
+			inputMethod:       http.MethodPut,
+			inputBody:         "LOG_UPTO(LOG_ERR)",
+			expectedCode:      http.StatusOK,
+			expectedMask:      "LOG_UPTO(LOG_ERR)",
+			expectedRevert:    true,
+			expectedDurations: []time.Duration{DefaultAdminRevert},
+		},
+		"put with revert": {
+			inputMethod:       http.MethodPut,
+			inputTarget:       "/?revert=1h",
+			inputBody:         "LOG_MASK(LOG_ERR)",
+			expectedCode:      http.StatusOK,
+			expectedMask:      "LOG_MASK(LOG_ERR)",
+			expectedRevert:    true,
+			expectedDurations: []time.Duration{time.Hour},
+		},
+		"put bad mask": {
+			inputMethod:  http.MethodPut,
+			inputBody:    "LOG_UPTO(LOG_LOUD)",
+			expectedCode: http.StatusBadRequest,
+		},
+		"put bad revert": {
+			inputMethod:  http.MethodPut,
+			inputTarget:  "/?revert=later",
+			inputBody:    "LOG_UPTO(LOG_ERR)",
+			expectedCode: http.StatusBadRequest,
+		},
+		"put without SetLogMask": {
+			inputMethod:  http.MethodPut,
+			inputBody:    "LOG_UPTO(LOG_ERR)",
+			useLimited:   true,
+			expectedCode: http.StatusConflict,
+		},
+		"get without description": {
+			inputMethod:  http.MethodGet,
+			useLimited:   true,
+			expectedCode: http.StatusOK,
+		},
+		"delete": {
+			inputMethod:  http.MethodDelete,
+			expectedCode: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for explanation, test := range tests {
+		tms := &timedMaskSyslogger{}
+		tms.SetLogMask(mask.UpTo(pri.Info))
+
+		if test.useLimited {
+			SetSyslogger(new(limitedSyslogger))
+		} else {
+			SetSyslogger(tms)
+		}
+
+		target := test.inputTarget
+		if target == "" {
+			target = "/"
+		}
+
+		a := &Admin{}
+		w := httptest.NewRecorder()
+		a.ServeHTTP(
+			w,
+			httptest.NewRequest(
+				test.inputMethod,
+				target,
+				strings.NewReader(test.inputBody),
+			),
+		)
+
+		assert.Equal(
+			t,
+			test.expectedCode,
+			w.Code,
+			"Admin test expects a different status for: %s",
+			explanation,
+		)
+		assert.Equal(
+			t,
+			test.expectedDurations,
+			tms.Durations,
+			"Admin test expects SetLogMaskFor durations for: %s",
+			explanation,
+		)
+
+		if w.Code != http.StatusOK {
+			continue
+		}
+
+		var actual adminState
+		e := json.Unmarshal(w.Body.Bytes(), &actual)
+		assert.NoError(
+			t,
+			e,
+			"Admin test expects a JSON response for: %s",
+			explanation,
+		)
+
+		assert.Equal(
+			t,
+			test.expectedMask,
+			actual.Mask,
+			"Admin test expects a different mask for: %s",
+			explanation,
+		)
+		assert.Equal(
+			t,
+			test.expectedRevert,
+			actual.Revert != nil,
+			"Admin test expects a different revert for: %s",
+			explanation,
+		)
+		assert.Len(
+			t,
+			actual.Counters,
+			8,
+			"Admin test expects a counter for each severity for:"+
+				" %s",
+			explanation,
+		)
+
+		if !test.useLimited {
+			assert.Equal(
+				t,
+				"LOG_LOCAL0",
+				actual.Facility,
+				"Admin test expects the facility for: %s",
+				explanation,
+			)
+		}
+	}
+}
+
+func TestAdminRevert(t *testing.T) {
+	m := &maskSyslogger{}
+	SetSyslogger(m)
+
+	m.SetLogMask(mask.UpTo(pri.Info))
+
+	a := &Admin{}
+	put := func(lm string) {
+		w := httptest.NewRecorder()
+		a.ServeHTTP(
+			w,
+			httptest.NewRequest(
+				http.MethodPut,
+				"/?revert=50ms",
+				strings.NewReader(lm),
+			),
+		)
+		assert.Equal(t, http.StatusOK, w.Code, "Error during PUT")
+	}
+
+	put("LOG_UPTO(LOG_DEBUG)")
+	put("LOG_UPTO(LOG_ERR)")
+
+	assert.Equal(
+		t,
+		mask.UpTo(pri.Err),
+		m.LogMask(),
+		"Admin revert test expects the mask to be set.",
+	)
+
+	assert.True(
+		t,
+		waitFor(func() bool {
+			return m.LogMask() == mask.UpTo(pri.Info)
+		}),
+		"Admin revert test expects the original mask to be restored.",
+	)
+
+	m.x.Lock()
+	actual := m.Masks
+	m.x.Unlock()
+
+	assert.Equal(
+		t,
+		[]mask.Mask{
+			mask.UpTo(pri.Info),
+			mask.UpTo(pri.Debug),
+			mask.UpTo(pri.Err),
+			mask.UpTo(pri.Info),
+		},
+		actual,
+		"Admin revert test expects the original mask to be restored"+
+			" exactly once.",
+	)
+}
+
+func TestAdminCounters(t *testing.T) {
+	s := new(testSyslogger)
+	SetSyslogger(s)
+
+	before := (&Admin{}).state().Counters
+
+	_ = Warning("counted")
+	s.TriggerError = true
+	_ = Warning("counted")
+	_ = Debug("counted")
+
+	after := (&Admin{}).state().Counters
+
+	type testCase struct {
+		severity       string
+		expectedLogged uint64
+		expectedFailed uint64
+	}
+
+	tests := map[string]testCase{
+		"warning": {
+			severity:       "LOG_WARNING",
+			expectedLogged: 1,
+			expectedFailed: 1,
+		},
+		"debug": {
+			severity:       "LOG_DEBUG",
+			expectedFailed: 1,
+		},
+		"info": {
+			severity: "LOG_INFO",
+		},
+	}
+
+	for explanation, test := range tests {
+		b, a := before[test.severity], after[test.severity]
+
+		assert.Equal(
+			t,
+			test.expectedLogged,
+			a.Logged-b.Logged,
+			"Admin counter test expects logged messages for: %s",
+			explanation,
+		)
+		assert.Equal(
+			t,
+			test.expectedFailed,
+			a.Failed-b.Failed,
+			"Admin counter test expects failed messages for: %s",
+			explanation,
+		)
+	}
+}
@@ -0,0 +1,336 @@
+package config
+
+import (
+	"io"
+	"os"
+
+	"github.com/proidiot/gone/log/syslogger"
+)
+
+var configOsStderr = os.Stderr
+var configOsStdout = os.Stdout
+var configOsOpenFile = os.OpenFile
+var configNewNativeSyslog = syslogger.NewNativeSyslog
+var configDialNativeSyslog = syslogger.DialNativeSyslog
+
+func init() {
+	Register("circuitbreaker", buildCircuitBreaker)
+	Register("console", buildConsole)
+	Register("delay", buildDelay)
+	Register("facilitymask", buildFacilityMask)
+	Register("fallthrough", buildFallthrough)
+	Register("filter", buildFilter)
+	Register("humanreadable", buildHumanReadable)
+	Register("multi", buildMulti)
+	Register("multiline", buildMultiline)
+	Register("native", buildNative)
+	Register("newliner", buildNewliner)
+	Register("nowait", buildNoWait)
+	Register("pool", buildPool)
+	Register("posixish", buildPosixish)
+	Register("retry", buildRetry)
+	Register("rfc3164", buildRfc3164)
+	Register("router", buildRouter)
+	Register("sanitizer", buildSanitizer)
+	Register("severitymask", buildSeverityMask)
+	Register("spool", buildSpool)
+	Register("stacktrace", buildStacktrace)
+	Register("writer", buildWriter)
+}
+
+func buildCircuitBreaker(n *Node) (syslogger.Syslogger, error) {
+	return &syslogger.CircuitBreaker{
+		Syslogger: n.Syslogger("syslogger"),
+		Threshold: n.Int("threshold"),
+		Cooldown:  n.Duration("cooldown"),
+	}, nil
+}
+
+func buildConsole(n *Node) (syslogger.Syslogger, error) {
+	f, c := configOsStderr, io.Closer(nil)
+	if n.Has("file") {
+		f, c = file(n, "file")
+	}
+
+	return &syslogger.Console{
+		File:  f,
+		Ident: n.String("ident"),
+		Pid:   n.Bool("pid"),
+		Color: syslogger.ColorMode(
+			n.Choice("color", "auto", "always", "never"),
+		),
+		Closer: c,
+	}, nil
+}
+
+// buildDelay builds the wrapped syslogger.Syslogger the first time it is
+// needed, so any problem with its configuration is only reported then.
+func buildDelay(n *Node) (syslogger.Syslogger, error) {
+	if !n.Require("syslogger") {
+		return nil, n.Err()
+	}
+
+	c := n.Node("syslogger")
+	if c == nil {
+		return nil, n.Err()
+	}
+
+	return syslogger.NewDelay(c.Build)
+}
+
+func buildFacilityMask(n *Node) (syslogger.Syslogger, error) {
+	return &syslogger.FacilityMask{
+		Syslogger: n.Syslogger("syslogger"),
+		Mask:      n.FacilityMask("mask"),
+		Facility:  n.Facility("facility"),
+	}, nil
+}
+
+func buildFallthrough(n *Node) (syslogger.Syslogger, error) {
+	return &syslogger.Fallthrough{
+		Default:     n.Syslogger("default"),
+		Fallthrough: n.Syslogger("fallthrough"),
+	}, nil
+}
+
+func buildFilter(n *Node) (syslogger.Syslogger, error) {
+	return &syslogger.Filter{
+		Syslogger: n.Syslogger("syslogger"),
+		Selector:  n.Selector("selector"),
+		Facility:  n.Facility("facility"),
+	}, nil
+}
+
+func buildHumanReadable(n *Node) (syslogger.Syslogger, error) {
+	return &syslogger.HumanReadable{
+		Syslogger: n.Syslogger("syslogger"),
+		Ident:     n.String("ident"),
+		Facility:  n.Facility("facility"),
+		Pid:       n.Bool("pid"),
+		Caller:    n.Bool("caller"),
+	}, nil
+}
+
+func buildMulti(n *Node) (syslogger.Syslogger, error) {
+	return &syslogger.Multi{
+		Sysloggers: n.Sysloggers("sysloggers"),
+		TryAll:     n.Bool("try_all"),
+	}, nil
+}
+
+func buildMultiline(n *Node) (syslogger.Syslogger, error) {
+	return &syslogger.Multiline{
+		Syslogger: n.Syslogger("syslogger"),
+		Policy: syslogger.MultilinePolicy(
+			n.Choice("policy", "escape", "split", "intact"),
+		),
+		Marker: n.String("marker"),
+	}, nil
+}
+
+func buildNative(n *Node) (syslogger.Syslogger, error) {
+	f := n.Facility("facility")
+	ident := n.String("ident")
+	network := n.String("network")
+	raddr := n.String("address")
+	if n.Err() != nil {
+		return nil, n.Err()
+	}
+
+	if raddr == "" {
+		return configNewNativeSyslog(f, ident)
+	}
+
+	return configDialNativeSyslog(network, raddr, f, ident)
+}
+
+func buildNewliner(n *Node) (syslogger.Syslogger, error) {
+	return &syslogger.Newliner{
+		Syslogger: n.Syslogger("syslogger"),
+	}, nil
+}
+
+func buildNoWait(n *Node) (syslogger.Syslogger, error) {
+	return &syslogger.NoWait{
+		Syslogger: n.Syslogger("syslogger"),
+	}, nil
+}
+
+func buildPool(n *Node) (syslogger.Syslogger, error) {
+	return &syslogger.Pool{
+		Sysloggers: n.Sysloggers("sysloggers"),
+		Strategy: syslogger.PoolStrategy(
+			n.Choice(
+				"strategy",
+				"failover",
+				"roundrobin",
+				"hashident",
+			),
+		),
+		Ident:         n.String("ident"),
+		ProbeInterval: n.Duration("probe_interval"),
+	}, nil
+}
+
+func buildPosixish(n *Node) (syslogger.Syslogger, error) {
+	ident := n.String("ident")
+	o := n.Option("options")
+	f := n.Facility("facility")
+	m := n.Mask("mask")
+	fm := n.FacilityMask("facility_mask")
+	sel := n.Selector("selector")
+	if n.Err() != nil {
+		return nil, n.Err()
+	}
+
+	px := &syslogger.Posixish{}
+	if e := px.Openlog(ident, o, f); e != nil {
+		return nil, e
+	}
+
+	if n.Has("mask") {
+		px.SetLogMask(m)
+	}
+
+	if n.Has("facility_mask") {
+		px.SetFacilityMask(fm)
+	}
+
+	if n.Has("selector") {
+		px.SetSelector(sel)
+	}
+
+	return px, nil
+}
+
+func buildRetry(n *Node) (syslogger.Syslogger, error) {
+	return &syslogger.Retry{
+		Syslogger:  n.Syslogger("syslogger"),
+		Attempts:   n.Int("attempts"),
+		Backoff:    n.Duration("backoff"),
+		MaxBackoff: n.Duration("max_backoff"),
+	}, nil
+}
+
+func buildRfc3164(n *Node) (syslogger.Syslogger, error) {
+	return &syslogger.Rfc3164{
+		Syslogger: n.Syslogger("syslogger"),
+		Ident:     n.String("ident"),
+		Facility:  n.Facility("facility"),
+		Pid:       n.Bool("pid"),
+		Caller:    n.Bool("caller"),
+		MaxLength: n.Int("max_length"),
+		Overflow: syslogger.Overflow(
+			n.Choice("overflow", "error", "truncate", "split"),
+		),
+	}, nil
+}
+
+func buildRouter(n *Node) (syslogger.Syslogger, error) {
+	r := &syslogger.Router{
+		Ident:    n.String("ident"),
+		Facility: n.Facility("facility"),
+	}
+
+	if n.Has("default") {
+		r.Default = n.Syslogger("default")
+	}
+
+	for _, c := range n.Nodes("routes") {
+		rt := syslogger.Route{
+			Syslogger:  c.Syslogger("syslogger"),
+			Facilities: c.Facilities("facilities"),
+			Idents:     c.Strings("idents"),
+			Pattern:    c.Regexp("pattern"),
+			Final:      c.Bool("final"),
+		}
+
+		if c.Has("severities") {
+			rt.Severities = c.Mask("severities")
+		}
+
+		if e := c.Check("a router route"); e != nil {
+			discard(r, rt.Syslogger)
+			return nil, e
+		}
+
+		r.Routes = append(r.Routes, rt)
+	}
+
+	return r, nil
+}
+
+func buildSanitizer(n *Node) (syslogger.Syslogger, error) {
+	return &syslogger.Sanitizer{
+		Syslogger: n.Syslogger("syslogger"),
+		Bom:       n.Bool("bom"),
+	}, nil
+}
+
+func buildSeverityMask(n *Node) (syslogger.Syslogger, error) {
+	return &syslogger.SeverityMask{
+		Syslogger: n.Syslogger("syslogger"),
+		Mask:      n.Mask("mask"),
+	}, nil
+}
+
+func buildSpool(n *Node) (syslogger.Syslogger, error) {
+	return &syslogger.Spool{
+		Syslogger:     n.Syslogger("syslogger"),
+		Dir:           n.String("dir"),
+		MaxBytes:      n.Int64("max_bytes"),
+		MaxAge:        n.Duration("max_age"),
+		SegmentBytes:  n.Int64("segment_bytes"),
+		ProbeInterval: n.Duration("probe_interval"),
+	}, nil
+}
+
+func buildStacktrace(n *Node) (syslogger.Syslogger, error) {
+	st := &syslogger.Stacktrace{
+		Syslogger: n.Syslogger("syslogger"),
+	}
+
+	if n.Has("severities") {
+		st.Severities = n.Mask("severities")
+	}
+
+	return st, nil
+}
+
+func buildWriter(n *Node) (syslogger.Syslogger, error) {
+	f, c := file(n, "file")
+
+	return &syslogger.Writer{
+		Writer: f,
+		Closer: c,
+	}, nil
+}
+
+// file gives the file named by the value of a key, which is either "stderr",
+// "stdout", or the path of a file to append to. A file which was opened is also
+// given as an io.Closer, so that the syslogger.Syslogger using it can close it
+// once it is replaced, while stderr and stdout are never closed.
+func file(n *Node, key string) (*os.File, io.Closer) {
+	if !n.Require(key) {
+		return nil, nil
+	}
+
+	switch name := n.String(key); name {
+	case "stderr":
+		return configOsStderr, nil
+	case "stdout":
+		return configOsStdout, nil
+	default:
+		f, e := configOsOpenFile(
+			name,
+			os.O_WRONLY|os.O_APPEND|os.O_CREATE,
+			0644,
+		)
+		if e != nil {
+			n.Errorf(key, "%s", e)
+			return nil, nil
+		}
+
+		return f, f
+	}
+}
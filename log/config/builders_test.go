@@ -0,0 +1,512 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/proidiot/gone/errors"
+	"github.com/proidiot/gone/log/mask"
+	"github.com/proidiot/gone/log/pri"
+	"github.com/proidiot/gone/log/syslogger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilders(t *testing.T) {
+	origNewNativeSyslog := configNewNativeSyslog
+	origDialNativeSyslog := configDialNativeSyslog
+	defer func() {
+		configNewNativeSyslog = origNewNativeSyslog
+		configDialNativeSyslog = origDialNativeSyslog
+	}()
+
+	var nativeArgs []string
+	configNewNativeSyslog = func(
+		f pri.Priority,
+		ident string,
+	) (*syslogger.NativeSyslog, error) {
+		nativeArgs = []string{f.String(), ident}
+		return &syslogger.NativeSyslog{}, nil
+	}
+	configDialNativeSyslog = func(
+		network string,
+		raddr string,
+		f pri.Priority,
+		ident string,
+	) (*syslogger.NativeSyslog, error) {
+		if raddr == "unreachable" {
+			return nil, errors.New("Artificial dial error")
+		}
+
+		nativeArgs = []string{network, raddr, f.String(), ident}
+		return &syslogger.NativeSyslog{}, nil
+	}
+
+	dir, e := ioutil.TempDir("", "config")
+	assert.NoError(t, e, "Error during TempDir")
+	defer os.RemoveAll(dir)
+
+	leaf := `{"type": "test", "name": "leaf"}`
+	leafSyslogger := &testSyslogger{Name: "leaf"}
+
+	type testCase struct {
+		input              string
+		expected           syslogger.Syslogger
+		expectedType       syslogger.Syslogger
+		expectedNativeArgs []string
+		expectedError      string
+	}
+
+	tests := map[string]testCase{
+		"circuitbreaker": {
+			input: `{
+				"type": "circuitbreaker",
+				"threshold": 2,
+				"cooldown": "1m",
+				"syslogger": ` + leaf + `
+			}`,
+			expected: &syslogger.CircuitBreaker{
+				Syslogger: leafSyslogger,
+				Threshold: 2,
+				Cooldown:  time.Minute,
+			},
+		},
+		"console": {
+			input: `{
+				"type": "console",
+				"ident": "app",
+				"pid": true,
+				"color": "never"
+			}`,
+			expected: &syslogger.Console{
+				File:  os.Stderr,
+				Ident: "app",
+				Pid:   true,
+				Color: syslogger.ColorNever,
+			},
+		},
+		"delay": {
+			input: `{
+				"type": "delay",
+				"syslogger": ` + leaf + `
+			}`,
+			expectedType: &syslogger.Delay{},
+		},
+		"delay without syslogger": {
+			input:         `{"type": "delay"}`,
+			expectedError: "key syslogger is required",
+		},
+		"facilitymask": {
+			input: `{
+				"type": "facilitymask",
+				"mask": "LOG_AUTH|LOG_MAIL",
+				"facility": "LOG_LOCAL1",
+				"syslogger": ` + leaf + `
+			}`,
+			expected: &syslogger.FacilityMask{
+				Syslogger: leafSyslogger,
+				Mask: mask.FacilityOf(pri.Auth) |
+					mask.FacilityOf(pri.Mail),
+				Facility: pri.Local1,
+			},
+		},
+		"fallthrough": {
+			input: `{
+				"type": "fallthrough",
+				"default": ` + leaf + `,
+				"fallthrough": {"type": "test", "name": "other"}
+			}`,
+			expected: &syslogger.Fallthrough{
+				Default:     leafSyslogger,
+				Fallthrough: &testSyslogger{Name: "other"},
+			},
+		},
+		"filter": {
+			input: `{
+				"type": "filter",
+				"selector": "*.none",
+				"syslogger": ` + leaf + `
+			}`,
+			expected: &syslogger.Filter{
+				Syslogger: leafSyslogger,
+			},
+		},
+		"humanreadable": {
+			input: `{
+				"type": "humanreadable",
+				"ident": "app",
+				"facility": "LOG_DAEMON",
+				"pid": true,
+				"caller": true,
+				"syslogger": ` + leaf + `
+			}`,
+			expected: &syslogger.HumanReadable{
+				Syslogger: leafSyslogger,
+				Ident:     "app",
+				Facility:  pri.Daemon,
+				Pid:       true,
+				Caller:    true,
+			},
+		},
+		"multi": {
+			input: `{
+				"type": "multi",
+				"try_all": true,
+				"sysloggers": [` + leaf + `, ` + leaf + `]
+			}`,
+			expected: &syslogger.Multi{
+				Sysloggers: []syslogger.Syslogger{
+					leafSyslogger,
+					leafSyslogger,
+				},
+				TryAll: true,
+			},
+		},
+		"multiline": {
+			input: `{
+				"type": "multiline",
+				"policy": "split",
+				"marker": "> ",
+				"syslogger": ` + leaf + `
+			}`,
+			expected: &syslogger.Multiline{
+				Syslogger: leafSyslogger,
+				Policy:    syslogger.MultilineSplit,
+				Marker:    "> ",
+			},
+		},
+		"multiline bad policy": {
+			input: `{
+				"type": "multiline",
+				"policy": "wrap",
+				"syslogger": ` + leaf + `
+			}`,
+			expectedError: "key policy has an invalid value",
+		},
+		"native local": {
+			input: `{
+				"type": "native",
+				"facility": "LOG_MAIL",
+				"ident": "app"
+			}`,
+			expectedType:       &syslogger.NativeSyslog{},
+			expectedNativeArgs: []string{"LOG_MAIL", "app"},
+		},
+		"native remote": {
+			input: `{
+				"type": "native",
+				"network": "tcp",
+				"address": "collector:514",
+				"ident": "app"
+			}`,
+			expectedType: &syslogger.NativeSyslog{},
+			expectedNativeArgs: []string{
+				"tcp",
+				"collector:514",
+				"LOG_EMERG",
+				"app",
+			},
+		},
+		"native dial error": {
+			input: `{
+				"type": "native",
+				"network": "tcp",
+				"address": "unreachable"
+			}`,
+			expectedError: "Artificial dial error",
+		},
+		"newliner": {
+			input: `{
+				"type": "newliner",
+				"syslogger": ` + leaf + `
+			}`,
+			expected: &syslogger.Newliner{
+				Syslogger: leafSyslogger,
+			},
+		},
+		"nowait": {
+			input: `{
+				"type": "nowait",
+				"syslogger": ` + leaf + `
+			}`,
+			expected: &syslogger.NoWait{
+				Syslogger: leafSyslogger,
+			},
+		},
+		"pool": {
+			input: `{
+				"type": "pool",
+				"strategy": "hashident",
+				"ident": "app",
+				"probe_interval": "5s",
+				"sysloggers": [` + leaf + `]
+			}`,
+			expected: &syslogger.Pool{
+				Sysloggers: []syslogger.Syslogger{
+					leafSyslogger,
+				},
+				Strategy:      syslogger.PoolHashIdent,
+				Ident:         "app",
+				ProbeInterval: 5 * time.Second,
+			},
+		},
+		"posixish": {
+			input: `{
+				"type": "posixish",
+				"ident": "app",
+				"options": "LOG_ODELAY",
+				"facility": "LOG_LOCAL2",
+				"mask": "LOG_UPTO(LOG_INFO)",
+				"facility_mask": "*",
+				"selector": "*.*"
+			}`,
+			expectedType: &syslogger.Posixish{},
+		},
+		"posixish bad options": {
+			input: `{
+				"type": "posixish",
+				"options": "LOG_ODELAY|LOG_NDELAY"
+			}`,
+			expectedError: "mutually exclusive",
+		},
+		"retry": {
+			input: `{
+				"type": "retry",
+				"attempts": 4,
+				"backoff": "20ms",
+				"max_backoff": "2s",
+				"syslogger": ` + leaf + `
+			}`,
+			expected: &syslogger.Retry{
+				Syslogger:  leafSyslogger,
+				Attempts:   4,
+				Backoff:    20 * time.Millisecond,
+				MaxBackoff: 2 * time.Second,
+			},
+		},
+		"rfc3164": {
+			input: `{
+				"type": "rfc3164",
+				"ident": "app",
+				"facility": "LOG_LOCAL0",
+				"pid": true,
+				"caller": true,
+				"max_length": 2048,
+				"overflow": "truncate",
+				"syslogger": ` + leaf + `
+			}`,
+			expected: &syslogger.Rfc3164{
+				Syslogger: leafSyslogger,
+				Ident:     "app",
+				Facility:  pri.Local0,
+				Pid:       true,
+				Caller:    true,
+				MaxLength: 2048,
+				Overflow:  syslogger.OverflowTruncate,
+			},
+		},
+		"router": {
+			input: `{
+				"type": "router",
+				"ident": "app",
+				"facility": "LOG_USER",
+				"default": ` + leaf + `,
+				"routes": [
+					{
+						"syslogger": ` + leaf + `,
+						"facilities": ["LOG_AUTH"],
+						"severities": "LOG_ERR",
+						"idents": ["app"],
+						"pattern": "^x",
+						"final": true
+					},
+					{"syslogger": ` + leaf + `}
+				]
+			}`,
+			expected: &syslogger.Router{
+				Routes: []syslogger.Route{
+					{
+						Syslogger: leafSyslogger,
+						Facilities: []pri.Priority{
+							pri.Auth,
+						},
+						Severities: mask.Err,
+						Idents:     []string{"app"},
+						Pattern: regexp.MustCompile(
+							"^x",
+						),
+						Final: true,
+					},
+					{
+						Syslogger: leafSyslogger,
+					},
+				},
+				Default:  leafSyslogger,
+				Ident:    "app",
+				Facility: pri.User,
+			},
+		},
+		"router route unknown key": {
+			input: `{
+				"type": "router",
+				"routes": [
+					{
+						"syslogger": ` + leaf + `,
+						"fnial": true
+					}
+				]
+			}`,
+			expectedError: "key routes[0].fnial is not understood",
+		},
+		"sanitizer": {
+			input: `{
+				"type": "sanitizer",
+				"bom": true,
+				"syslogger": ` + leaf + `
+			}`,
+			expected: &syslogger.Sanitizer{
+				Syslogger: leafSyslogger,
+				Bom:       true,
+			},
+		},
+		"severitymask": {
+			input: `{
+				"type": "severitymask",
+				"mask": "LOG_MASK(LOG_ERR)",
+				"syslogger": ` + leaf + `
+			}`,
+			expected: &syslogger.SeverityMask{
+				Syslogger: leafSyslogger,
+				Mask:      mask.Err,
+			},
+		},
+		"spool": {
+			input: `{
+				"type": "spool",
+				"dir": "/var/spool/app",
+				"max_bytes": 1000,
+				"max_age": "1h",
+				"segment_bytes": 100,
+				"probe_interval": "10s",
+				"syslogger": ` + leaf + `
+			}`,
+			expected: &syslogger.Spool{
+				Syslogger:     leafSyslogger,
+				Dir:           "/var/spool/app",
+				MaxBytes:      1000,
+				MaxAge:        time.Hour,
+				SegmentBytes:  100,
+				ProbeInterval: 10 * time.Second,
+			},
+		},
+		"stacktrace": {
+			input: `{
+				"type": "stacktrace",
+				"severities": "LOG_UPTO(LOG_ERR)",
+				"syslogger": ` + leaf + `
+			}`,
+			expected: &syslogger.Stacktrace{
+				Syslogger:  leafSyslogger,
+				Severities: mask.UpTo(pri.Err),
+			},
+		},
+		"stacktrace default severities": {
+			input: `{
+				"type": "stacktrace",
+				"syslogger": ` + leaf + `
+			}`,
+			expected: &syslogger.Stacktrace{
+				Syslogger: leafSyslogger,
+			},
+		},
+		"writer stdout": {
+			input: `{"type": "writer", "file": "stdout"}`,
+			expected: &syslogger.Writer{
+				Writer: os.Stdout,
+			},
+		},
+		"writer file": {
+			input: `{
+				"type": "writer",
+				"file": "` + filepath.Join(dir, "log") + `"
+			}`,
+			expectedType: &syslogger.Writer{},
+		},
+		"writer missing file": {
+			input:         `{"type": "writer"}`,
+			expectedError: "key file is required",
+		},
+		"writer bad file": {
+			input: `{
+				"type": "writer",
+				"file": "` + filepath.Join(dir, "a", "b") + `"
+			}`,
+			expectedError: "key file has an invalid value",
+		},
+	}
+
+	for explanation, test := range tests {
+		nativeArgs = nil
+
+		actual, actualError := Build(strings.NewReader(test.input))
+
+		if test.expectedError != "" {
+			if assert.Error(
+				t,
+				actualError,
+				"Builder test expects an error for: %s",
+				explanation,
+			) {
+				assert.Contains(
+					t,
+					actualError.Error(),
+					test.expectedError,
+					"Builder test expects a different"+
+						" error for: %s",
+					explanation,
+				)
+			}
+
+			continue
+		}
+
+		assert.NoError(
+			t,
+			actualError,
+			"Builder test expects no error for: %s",
+			explanation,
+		)
+
+		if test.expectedType != nil {
+			assert.IsType(
+				t,
+				test.expectedType,
+				actual,
+				"Builder test expects a different type for: %s",
+				explanation,
+			)
+		} else {
+			assert.Equal(
+				t,
+				test.expected,
+				actual,
+				"Builder test expects a different syslogger"+
+					" for: %s",
+				explanation,
+			)
+		}
+
+		assert.Equal(
+			t,
+			test.expectedNativeArgs,
+			nativeArgs,
+			"Builder test expects different native syslog"+
+				" arguments for: %s",
+			explanation,
+		)
+	}
+}
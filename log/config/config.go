@@ -0,0 +1,120 @@
+// Package config builds syslogger.Syslogger pipelines from declarative JSON
+// configuration files.
+//
+// A configuration describes a single syslogger.Syslogger as a JSON object with
+// a "type" key naming a registered Builder, and other keys which are specific
+// to that type. Decorators hold the syslogger.Syslogger they wrap under a
+// "syslogger" key (or a list of them under a "sysloggers" key), so a whole
+// pipeline is a tree of these objects:
+//
+//	{
+//		"type": "severitymask",
+//		"mask": "LOG_UPTO(LOG_INFO)",
+//		"syslogger": {
+//			"type": "rfc3164",
+//			"ident": "app",
+//			"facility": "LOG_LOCAL0",
+//			"syslogger": {"type": "writer", "file": "stderr"}
+//		}
+//	}
+//
+// Every type in log/syslogger is registered under its lowercased name (such
+// as "rfc3164" or "circuitbreaker"), and other types can be added with
+// Register.
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sync"
+
+	"github.com/proidiot/gone/errors"
+	"github.com/proidiot/gone/log/syslogger"
+)
+
+// Builder creates a syslogger.Syslogger from the configuration Node which
+// describes it. A Builder should use the Node accessors for every key it
+// understands, since a key which is not used causes an error.
+type Builder func(n *Node) (syslogger.Syslogger, error)
+
+var registry = map[string]Builder{}
+var registryX sync.RWMutex
+
+// Register makes a Builder available for configurations with the given type
+// name, replacing any Builder previously registered with that name.
+func Register(name string, b Builder) {
+	registryX.Lock()
+	defer registryX.Unlock()
+	registry[name] = b
+}
+
+func lookup(name string) Builder {
+	registryX.RLock()
+	defer registryX.RUnlock()
+	return registry[name]
+}
+
+// Build creates the syslogger.Syslogger described by the JSON configuration
+// read from r.
+func Build(r io.Reader) (syslogger.Syslogger, error) {
+	raw, e := ioutil.ReadAll(r)
+	if e != nil {
+		return nil, e
+	}
+
+	d := json.NewDecoder(bytes.NewReader(raw))
+	var v json.RawMessage
+	if e := d.Decode(&v); e != nil {
+		return nil, fmt.Errorf(
+			"The log configuration must be valid JSON, but it"+
+				" could not be parsed: %s",
+			e,
+		)
+	} else if d.More() {
+		return nil, errors.New(
+			"The log configuration must be a single JSON object," +
+				" but more data follows the first object.",
+		)
+	}
+
+	n, e := newNode("", v)
+	if e != nil {
+		return nil, e
+	}
+
+	return n.Build()
+}
+
+// Load creates the syslogger.Syslogger described by the JSON configuration in
+// the file at the given path.
+func Load(path string) (syslogger.Syslogger, error) {
+	f, e := os.Open(path)
+	if e != nil {
+		return nil, e
+	}
+	defer f.Close()
+
+	s, e := Build(f)
+	if e != nil {
+		return nil, fmt.Errorf("%s: %s", path, e)
+	}
+
+	return s, nil
+}
+
+// GetFromEnv creates the syslogger.Syslogger described by the JSON
+// configuration in the file named by the LOG_CONFIG environment variable. If
+// the variable is unset or empty, then no syslogger.Syslogger and no error is
+// given.
+func GetFromEnv() (syslogger.Syslogger, error) {
+	path := os.Getenv("LOG_CONFIG")
+	if path == "" {
+		return nil, nil
+	}
+
+	return Load(path)
+}
@@ -0,0 +1,395 @@
+package config
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/proidiot/gone/log/pri"
+	"github.com/proidiot/gone/log/syslogger"
+	"github.com/stretchr/testify/assert"
+)
+
+type testSyslogger struct {
+	Name string
+}
+
+func (t *testSyslogger) Syslog(p pri.Priority, msg interface{}) error {
+	return nil
+}
+
+// closeSyslogger counts how many times any closeSyslogger has been closed.
+type closeSyslogger struct {
+	testSyslogger
+}
+
+var closeSysloggerCloses int
+
+func (c *closeSyslogger) Close() error {
+	closeSysloggerCloses++
+	return nil
+}
+
+func init() {
+	Register("test", func(n *Node) (syslogger.Syslogger, error) {
+		return &testSyslogger{
+			Name: n.String("name"),
+		}, nil
+	})
+	Register("close", func(n *Node) (syslogger.Syslogger, error) {
+		return &closeSyslogger{
+			testSyslogger{Name: n.String("name")},
+		}, nil
+	})
+}
+
+func TestBuild(t *testing.T) {
+	type testCase struct {
+		input         string
+		expected      syslogger.Syslogger
+		expectedError string
+	}
+
+	tests := map[string]testCase{
+		"registered type": {
+			input:    `{"type": "test", "name": "a"}`,
+			expected: &testSyslogger{Name: "a"},
+		},
+		"nested type": {
+			input: `{
+				"type": "newliner",
+				"syslogger": {"type": "test", "name": "b"}
+			}`,
+			expected: &syslogger.Newliner{
+				Syslogger: &testSyslogger{Name: "b"},
+			},
+		},
+		"invalid json": {
+			input:         `{"type": "test"`,
+			expectedError: "valid JSON",
+		},
+		"trailing data": {
+			input:         `{"type": "test"} {}`,
+			expectedError: "single JSON object",
+		},
+		"not an object": {
+			input:         `["test"]`,
+			expectedError: "at the top level must be a JSON object",
+		},
+		"missing type": {
+			input:         `{"name": "a"}`,
+			expectedError: "key type is required",
+		},
+		"unregistered type": {
+			input:         `{"type": "bogus"}`,
+			expectedError: `"bogus" is not registered`,
+		},
+		"unknown key": {
+			input:         `{"type": "test", "nmae": "a"}`,
+			expectedError: "key nmae is not understood",
+		},
+		"nested unknown key": {
+			input: `{
+				"type": "newliner",
+				"syslogger": {"type": "test", "nmae": "b"}
+			}`,
+			expectedError: "key syslogger.nmae is not understood",
+		},
+		"nested wrong type": {
+			input: `{
+				"type": "multi",
+				"sysloggers": [
+					{"type": "test"},
+					{"type": "test", "name": 7}
+				]
+			}`,
+			expectedError: "key sysloggers[1].name has an invalid",
+		},
+	}
+
+	for explanation, test := range tests {
+		actual, actualError := Build(strings.NewReader(test.input))
+
+		if test.expectedError != "" {
+			if assert.Error(
+				t,
+				actualError,
+				"Build test expects an error for: %s",
+				explanation,
+			) {
+				assert.Contains(
+					t,
+					actualError.Error(),
+					test.expectedError,
+					"Build test expects a different error"+
+						" for: %s",
+					explanation,
+				)
+			}
+		} else {
+			assert.NoError(
+				t,
+				actualError,
+				"Build test expects no error for: %s",
+				explanation,
+			)
+			assert.Equal(
+				t,
+				test.expected,
+				actual,
+				"Build test expects a different syslogger"+
+					" for: %s",
+				explanation,
+			)
+		}
+	}
+}
+
+func TestBuildCloses(t *testing.T) {
+	dir, e := ioutil.TempDir("", "config")
+	assert.NoError(t, e, "Error during TempDir")
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "log")
+
+	var opened []*os.File
+	origOsOpenFile := configOsOpenFile
+	defer func() {
+		configOsOpenFile = origOsOpenFile
+	}()
+	configOsOpenFile = func(
+		name string,
+		flag int,
+		perm os.FileMode,
+	) (*os.File, error) {
+		f, e := origOsOpenFile(name, flag, perm)
+		if e == nil {
+			opened = append(opened, f)
+		}
+		return f, e
+	}
+
+	type testCase struct {
+		input          string
+		expectedError  bool
+		expectedCloses int
+	}
+
+	tests := map[string]testCase{
+		"built": {
+			input: `{"type": "close"}`,
+		},
+		"unknown key": {
+			input:          `{"type": "close", "nmae": "a"}`,
+			expectedError:  true,
+			expectedCloses: 1,
+		},
+		"parent unknown key": {
+			input: `{
+				"type": "newliner",
+				"syslogger": {"type": "close"},
+				"nmae": "a"
+			}`,
+			expectedError:  true,
+			expectedCloses: 1,
+		},
+		"later sibling": {
+			input: `{
+				"type": "multi",
+				"sysloggers": [
+					{"type": "close"},
+					{"type": "close"},
+					{"type": "close", "nmae": "a"}
+				]
+			}`,
+			expectedError:  true,
+			expectedCloses: 3,
+		},
+		"later sibling type": {
+			input: `{
+				"type": "multi",
+				"sysloggers": [
+					{"type": "close"},
+					{"type": "bogus"}
+				]
+			}`,
+			expectedError:  true,
+			expectedCloses: 1,
+		},
+		"fallthrough": {
+			input: `{
+				"type": "fallthrough",
+				"default": {"type": "close"},
+				"fallthrough": {"type": "bogus"}
+			}`,
+			expectedError:  true,
+			expectedCloses: 1,
+		},
+		"opened file": {
+			input: `{
+				"type": "multi",
+				"sysloggers": [
+					{
+						"type": "writer",
+						"file": "` + path + `"
+					},
+					{
+						"type": "writer",
+						"file": "stderr",
+						"typo": 1
+					}
+				]
+			}`,
+			expectedError: true,
+		},
+		"router route": {
+			input: `{
+				"type": "router",
+				"default": {"type": "close"},
+				"routes": [
+					{"syslogger": {"type": "close"}},
+					{
+						"syslogger": {"type": "close"},
+						"nmae": "a"
+					}
+				]
+			}`,
+			expectedError:  true,
+			expectedCloses: 3,
+		},
+	}
+
+	for explanation, test := range tests {
+		closeSysloggerCloses = 0
+		opened = nil
+
+		actual, actualError := Build(strings.NewReader(test.input))
+
+		if test.expectedError {
+			assert.Error(
+				t,
+				actualError,
+				"Build close test expects an error for: %s",
+				explanation,
+			)
+		} else {
+			assert.NoError(
+				t,
+				actualError,
+				"Build close test expects no error for: %s",
+				explanation,
+			)
+		}
+
+		assert.Equal(
+			t,
+			test.expectedCloses,
+			closeSysloggerCloses,
+			"Build close test expects a specific number of closes"+
+				" for: %s",
+			explanation,
+		)
+
+		for _, f := range opened {
+			_, e := f.Stat()
+			assert.Equal(
+				t,
+				test.expectedError,
+				e != nil,
+				"Build close test expects opened files to be"+
+					" closed only on error for: %s",
+				explanation,
+			)
+		}
+
+		if c, ok := actual.(io.Closer); ok {
+			_ = c.Close()
+		}
+	}
+}
+
+func TestLoadAndGetFromEnv(t *testing.T) {
+	dir, e := ioutil.TempDir("", "config")
+	assert.NoError(t, e, "Error during TempDir")
+	defer os.RemoveAll(dir)
+
+	good := filepath.Join(dir, "good.json")
+	e = ioutil.WriteFile(good, []byte(`{"type":"test","name":"c"}`), 0600)
+	assert.NoError(t, e, "Error during WriteFile")
+
+	bad := filepath.Join(dir, "bad.json")
+	e = ioutil.WriteFile(bad, []byte(`{"type":"bogus"}`), 0600)
+	assert.NoError(t, e, "Error during WriteFile")
+
+	type testCase struct {
+		input         *string
+		expected      syslogger.Syslogger
+		expectedError bool
+	}
+
+	str := func(s string) *string {
+		return &s
+	}
+
+	tests := map[string]testCase{
+		"not set": {
+			input: nil,
+		},
+		"empty": {
+			input: str(""),
+		},
+		"good file": {
+			input:    &good,
+			expected: &testSyslogger{Name: "c"},
+		},
+		"bad file": {
+			input:         &bad,
+			expectedError: true,
+		},
+		"missing file": {
+			input:         str(filepath.Join(dir, "missing.json")),
+			expectedError: true,
+		},
+	}
+
+	for explanation, test := range tests {
+		e := os.Unsetenv("LOG_CONFIG")
+		assert.NoError(t, e, "Error during Unsetenv")
+
+		if test.input != nil {
+			e := os.Setenv("LOG_CONFIG", *test.input)
+			assert.NoError(t, e, "Error during Setenv")
+		}
+
+		actual, actualError := GetFromEnv()
+
+		if test.expectedError {
+			assert.Error(
+				t,
+				actualError,
+				"GetFromEnv test expects an error for: %s",
+				explanation,
+			)
+		} else {
+			assert.NoError(
+				t,
+				actualError,
+				"GetFromEnv test expects no error for: %s",
+				explanation,
+			)
+			assert.Equal(
+				t,
+				test.expected,
+				actual,
+				"GetFromEnv test expects a different"+
+					" syslogger for: %s",
+				explanation,
+			)
+		}
+	}
+
+	e = os.Unsetenv("LOG_CONFIG")
+	assert.NoError(t, e, "Error during Unsetenv")
+}
@@ -0,0 +1,462 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/proidiot/gone/log/mask"
+	"github.com/proidiot/gone/log/opt"
+	"github.com/proidiot/gone/log/pri"
+	"github.com/proidiot/gone/log/syslogger"
+)
+
+// Node is a single JSON object within a log configuration. Each Node knows its
+// own path within the configuration (such as "routes[0].syslogger") so that any
+// error it gives can point to the offending key. The accessors give the zero
+// value of the requested type (or the noted default) when a key is absent. When
+// a key has an invalid value they also give the zero value, and the first such
+// error is kept by the Node so that a Builder can use several accessors before
+// checking Err.
+type Node struct {
+	path string
+	vals map[string]json.RawMessage
+	used map[string]bool
+	err  error
+}
+
+func newNode(path string, raw json.RawMessage) (*Node, error) {
+	n := &Node{
+		path: path,
+		used: map[string]bool{},
+	}
+
+	if e := json.Unmarshal(raw, &n.vals); e != nil || n.vals == nil {
+		return nil, fmt.Errorf(
+			"The log configuration value at %s must be a JSON"+
+				" object, but it has the value %s.",
+			n.where(),
+			raw,
+		)
+	}
+
+	return n, nil
+}
+
+// Path gives the location of the Node within the log configuration.
+func (n *Node) Path() string {
+	return n.path
+}
+
+// Has indicates whether the Node has the given key.
+func (n *Node) Has(key string) bool {
+	_, present := n.vals[key]
+	return present
+}
+
+// Err gives the first error encountered by an accessor of the Node.
+func (n *Node) Err() error {
+	return n.err
+}
+
+// Errorf creates an error about the value of the given key which includes the
+// path to that key, and keeps it as the Node error if there isn't one already.
+// The format should describe the problem with the value.
+func (n *Node) Errorf(key string, format string, a ...interface{}) error {
+	e := fmt.Errorf(
+		"The log configuration key %s has an invalid value: %s",
+		n.key(key),
+		fmt.Sprintf(format, a...),
+	)
+	n.fail(e)
+	return e
+}
+
+// String gives the string value of a key.
+func (n *Node) String(key string) string {
+	var s string
+	n.decode(key, &s, "a string")
+	return s
+}
+
+// Strings gives the value of a key which is a list of strings.
+func (n *Node) Strings(key string) []string {
+	var s []string
+	n.decode(key, &s, "a list of strings")
+	return s
+}
+
+// Bool gives the boolean value of a key.
+func (n *Node) Bool(key string) bool {
+	var b bool
+	n.decode(key, &b, "true or false")
+	return b
+}
+
+// Int gives the integer value of a key.
+func (n *Node) Int(key string) int {
+	var i int
+	n.decode(key, &i, "an integer")
+	return i
+}
+
+// Int64 gives the 64-bit integer value of a key.
+func (n *Node) Int64(key string) int64 {
+	var i int64
+	n.decode(key, &i, "an integer")
+	return i
+}
+
+// Duration gives the value of a key which is a string accepted by
+// time.ParseDuration (such as "1.5s").
+func (n *Node) Duration(key string) time.Duration {
+	s := n.String(key)
+	if s == "" {
+		return 0
+	}
+
+	d, e := time.ParseDuration(s)
+	if e != nil {
+		n.Errorf(key, "%s", e)
+		return 0
+	}
+
+	return d
+}
+
+// Facility gives the value of a key which is a facility name (such as
+// "LOG_LOCAL0").
+func (n *Node) Facility(key string) pri.Priority {
+	s := n.String(key)
+	if s == "" {
+		return 0
+	}
+
+	return n.facility(key, s)
+}
+
+// Facilities gives the value of a key which is a list of facility names.
+func (n *Node) Facilities(key string) []pri.Priority {
+	var fs []pri.Priority
+	for _, s := range n.Strings(key) {
+		fs = append(fs, n.facility(key, s))
+	}
+
+	return fs
+}
+
+func (n *Node) facility(key string, s string) pri.Priority {
+	f, e := pri.Parse(s)
+	if e == nil {
+		e = f.ValidFacility()
+	}
+	if e != nil {
+		n.Errorf(key, "%q is not a facility name", s)
+		return 0
+	}
+
+	return f
+}
+
+// Option gives the value of a key which is a bitwise or of option names (such
+// as "LOG_PID|LOG_PERROR").
+func (n *Node) Option(key string) opt.Option {
+	o, e := opt.Parse(n.String(key))
+	if e != nil {
+		n.Errorf(key, "%s", e)
+		return 0
+	}
+
+	return o
+}
+
+// Mask gives the value of a key which is a log mask (such as
+// "LOG_UPTO(LOG_ERR)"), or mask.Mask(0xFF) if the key is absent.
+func (n *Node) Mask(key string) mask.Mask {
+	s := n.String(key)
+	if s == "" {
+		return mask.Mask(0xFF)
+	}
+
+	m, e := mask.Parse(s)
+	if e != nil {
+		n.Errorf(key, "%s", e)
+		return 0
+	}
+
+	return m
+}
+
+// FacilityMask gives the value of a key which is a facility mask (such as
+// "LOG_AUTH|LOG_LOCAL0"), or mask.AllFacilities if the key is absent.
+func (n *Node) FacilityMask(key string) mask.Facility {
+	s := n.String(key)
+	if s == "" {
+		return mask.AllFacilities
+	}
+
+	f, e := mask.ParseFacility(s)
+	if e != nil {
+		n.Errorf(key, "%s", e)
+		return 0
+	}
+
+	return f
+}
+
+// Selector gives the value of a key which is a syslog.conf style selector
+// (such as "*.warn;mail.none"), or mask.SelectAll() if the key is absent.
+func (n *Node) Selector(key string) mask.Selector {
+	s := n.String(key)
+	if s == "" {
+		return mask.SelectAll()
+	}
+
+	sel, e := mask.ParseSelector(s)
+	if e != nil {
+		n.Errorf(key, "%s", e)
+	}
+
+	return sel
+}
+
+// Regexp gives the value of a key which is a regular expression, or nil if the
+// key is absent.
+func (n *Node) Regexp(key string) *regexp.Regexp {
+	s := n.String(key)
+	if s == "" {
+		return nil
+	}
+
+	re, e := regexp.Compile(s)
+	if e != nil {
+		n.Errorf(key, "%s", e)
+		return nil
+	}
+
+	return re
+}
+
+// Choice gives the value of a key which must be one of the given names, as the
+// index of that name. The first name is the default if the key is absent.
+func (n *Node) Choice(key string, names ...string) int {
+	s := n.String(key)
+	if s == "" {
+		return 0
+	}
+
+	for i, name := range names {
+		if s == name {
+			return i
+		}
+	}
+
+	n.Errorf(key, "%q is not one of %s", s, strings.Join(names, ", "))
+	return 0
+}
+
+// Node gives the value of a key which is itself a JSON object, or nil if the
+// key is absent.
+func (n *Node) Node(key string) *Node {
+	raw, present := n.vals[key]
+	if !present {
+		return nil
+	}
+
+	n.used[key] = true
+	c, e := newNode(n.key(key), raw)
+	if e != nil {
+		n.fail(e)
+		return nil
+	}
+
+	return c
+}
+
+// Nodes gives the value of a key which is a list of JSON objects.
+func (n *Node) Nodes(key string) []*Node {
+	var raws []json.RawMessage
+	n.decode(key, &raws, "a list of objects")
+
+	var nodes []*Node
+	for i, raw := range raws {
+		c, e := newNode(fmt.Sprintf("%s[%d]", n.key(key), i), raw)
+		if e != nil {
+			n.fail(e)
+			return nil
+		}
+
+		nodes = append(nodes, c)
+	}
+
+	return nodes
+}
+
+// Syslogger builds the syslogger.Syslogger described by the value of a key,
+// which is required.
+func (n *Node) Syslogger(key string) syslogger.Syslogger {
+	if !n.Require(key) {
+		return nil
+	}
+
+	c := n.Node(key)
+	if c == nil {
+		return nil
+	}
+
+	s, e := c.Build()
+	if e != nil {
+		n.fail(e)
+		return nil
+	}
+
+	return s
+}
+
+// Sysloggers builds the syslogger.Sysloggers described by the value of a key,
+// which is a list. If any of them can't be built, those which already were are
+// closed.
+func (n *Node) Sysloggers(key string) []syslogger.Syslogger {
+	var res []syslogger.Syslogger
+	for _, c := range n.Nodes(key) {
+		s, e := c.Build()
+		if e != nil {
+			discard(res...)
+			n.fail(e)
+			return nil
+		}
+
+		res = append(res, s)
+	}
+
+	return res
+}
+
+// Build creates the syslogger.Syslogger described by the Node, using the
+// Builder registered for the name given by its "type" key. An error is given
+// if the Builder (or any accessor it used) gave an error, or if the Builder
+// didn't use every key of the Node, in which case anything the Builder gave is
+// closed so that the files and connections it opened aren't leaked.
+func (n *Node) Build() (syslogger.Syslogger, error) {
+	if !n.Require("type") {
+		return nil, n.err
+	}
+
+	t := n.String("type")
+	if n.err != nil {
+		return nil, n.err
+	}
+
+	b := lookup(t)
+	if b == nil {
+		return nil, fmt.Errorf(
+			"The log configuration key %s must name a registered"+
+				" syslogger type, but %q is not registered.",
+			n.key("type"),
+			t,
+		)
+	}
+
+	s, e := b(n)
+	if e != nil {
+		return nil, e
+	}
+
+	if e := n.Check(fmt.Sprintf("the %q syslogger type", t)); e != nil {
+		discard(s)
+		return nil, e
+	}
+
+	return s, nil
+}
+
+// discard closes each of the given syslogger.Sysloggers which has a Close
+// function, since they were built for a configuration which can't be used.
+func discard(ss ...syslogger.Syslogger) {
+	for _, s := range ss {
+		if c, ok := s.(io.Closer); ok {
+			_ = c.Close()
+		}
+	}
+}
+
+// Require indicates whether the Node has the given key, and keeps an error as
+// the Node error if it doesn't.
+func (n *Node) Require(key string) bool {
+	if n.Has(key) {
+		return true
+	}
+
+	n.fail(fmt.Errorf(
+		"The log configuration key %s is required, but it is missing.",
+		n.key(key),
+	))
+	return false
+}
+
+// Check gives the Node error if there is one, or else an error about any key
+// of the Node which hasn't been used by an accessor. This is done by Build, but
+// a Builder which uses Nodes for something other than a syslogger.Syslogger
+// should Check them itself. The description of the Node is used in the error.
+func (n *Node) Check(what string) error {
+	if n.err != nil {
+		return n.err
+	}
+
+	var unused []string
+	for key := range n.vals {
+		if !n.used[key] {
+			unused = append(unused, n.key(key))
+		}
+	}
+
+	if len(unused) != 0 {
+		sort.Strings(unused)
+		return fmt.Errorf(
+			"The log configuration key %s is not understood by"+
+				" %s.",
+			unused[0],
+			what,
+		)
+	}
+
+	return nil
+}
+
+func (n *Node) decode(key string, v interface{}, kind string) {
+	raw, present := n.vals[key]
+	if !present {
+		return
+	}
+
+	n.used[key] = true
+	if e := json.Unmarshal(raw, v); e != nil {
+		n.Errorf(key, "expected %s but found %s", kind, raw)
+	}
+}
+
+func (n *Node) fail(e error) {
+	if n.err == nil {
+		n.err = e
+	}
+}
+
+func (n *Node) key(key string) string {
+	if n.path == "" {
+		return key
+	}
+
+	return n.path + "." + key
+}
+
+func (n *Node) where() string {
+	if n.path == "" {
+		return "the top level"
+	}
+
+	return n.path
+}
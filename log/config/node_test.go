@@ -0,0 +1,211 @@
+package config
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/proidiot/gone/log/mask"
+	"github.com/proidiot/gone/log/opt"
+	"github.com/proidiot/gone/log/pri"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNodeAccessors(t *testing.T) {
+	type testCase struct {
+		input         string
+		access        func(n *Node) interface{}
+		expected      interface{}
+		expectedError string
+	}
+
+	tests := map[string]testCase{
+		"string": {
+			input: `{"k": "v"}`,
+			access: func(n *Node) interface{} {
+				return n.String("k")
+			},
+			expected: "v",
+		},
+		"absent string": {
+			input: `{}`,
+			access: func(n *Node) interface{} {
+				return n.String("k")
+			},
+			expected: "",
+		},
+		"wrong type": {
+			input: `{"k": 1}`,
+			access: func(n *Node) interface{} {
+				return n.String("k")
+			},
+			expected:      "",
+			expectedError: "key k has an invalid value: expected",
+		},
+		"int": {
+			input: `{"k": 3}`,
+			access: func(n *Node) interface{} {
+				return n.Int("k")
+			},
+			expected: 3,
+		},
+		"duration": {
+			input: `{"k": "1.5s"}`,
+			access: func(n *Node) interface{} {
+				return n.Duration("k")
+			},
+			expected: 1500 * time.Millisecond,
+		},
+		"bad duration": {
+			input: `{"k": "soon"}`,
+			access: func(n *Node) interface{} {
+				return n.Duration("k")
+			},
+			expected:      time.Duration(0),
+			expectedError: "key k has an invalid value",
+		},
+		"facility": {
+			input: `{"k": "LOG_LOCAL0"}`,
+			access: func(n *Node) interface{} {
+				return n.Facility("k")
+			},
+			expected: pri.Local0,
+		},
+		"severity is not a facility": {
+			input: `{"k": "LOG_ERR"}`,
+			access: func(n *Node) interface{} {
+				return n.Facility("k")
+			},
+			expected:      pri.Priority(0),
+			expectedError: `"LOG_ERR" is not a facility name`,
+		},
+		"facilities": {
+			input: `{"k": ["LOG_MAIL", "LOG_AUTH"]}`,
+			access: func(n *Node) interface{} {
+				return n.Facilities("k")
+			},
+			expected: []pri.Priority{pri.Mail, pri.Auth},
+		},
+		"option": {
+			input: `{"k": "LOG_PID|LOG_PERROR"}`,
+			access: func(n *Node) interface{} {
+				return n.Option("k")
+			},
+			expected: opt.Pid | opt.Perror,
+		},
+		"mask": {
+			input: `{"k": "LOG_UPTO(LOG_ERR)"}`,
+			access: func(n *Node) interface{} {
+				return n.Mask("k")
+			},
+			expected: mask.UpTo(pri.Err),
+		},
+		"absent mask": {
+			input: `{}`,
+			access: func(n *Node) interface{} {
+				return n.Mask("k")
+			},
+			expected: mask.Mask(0xFF),
+		},
+		"facility mask": {
+			input: `{"k": "LOG_AUTH"}`,
+			access: func(n *Node) interface{} {
+				return n.FacilityMask("k")
+			},
+			expected: mask.FacilityOf(pri.Auth),
+		},
+		"absent selector": {
+			input: `{}`,
+			access: func(n *Node) interface{} {
+				return n.Selector("k")
+			},
+			expected: mask.SelectAll(),
+		},
+		"bad selector": {
+			input: `{"k": "mail"}`,
+			access: func(n *Node) interface{} {
+				return n.Selector("k")
+			},
+			expected:      mask.Selector{},
+			expectedError: "key k has an invalid value",
+		},
+		"bad regexp": {
+			input: `{"k": "("}`,
+			access: func(n *Node) interface{} {
+				return n.Regexp("k") == nil
+			},
+			expected:      true,
+			expectedError: "key k has an invalid value",
+		},
+		"choice": {
+			input: `{"k": "b"}`,
+			access: func(n *Node) interface{} {
+				return n.Choice("k", "a", "b")
+			},
+			expected: 1,
+		},
+		"bad choice": {
+			input: `{"k": "c"}`,
+			access: func(n *Node) interface{} {
+				return n.Choice("k", "a", "b")
+			},
+			expected:      0,
+			expectedError: `"c" is not one of a, b`,
+		},
+		"nested node path": {
+			input: `{"k": [{}, {"j": 1}]}`,
+			access: func(n *Node) interface{} {
+				return n.Nodes("k")[1].Path()
+			},
+			expected: "k[1]",
+		},
+		"missing required syslogger": {
+			input: `{}`,
+			access: func(n *Node) interface{} {
+				return n.Syslogger("k") == nil
+			},
+			expected:      true,
+			expectedError: "key k is required, but it is missing",
+		},
+	}
+
+	for explanation, test := range tests {
+		n, e := newNode("", json.RawMessage(test.input))
+		assert.NoError(t, e, "Error during newNode")
+
+		actual := test.access(n)
+
+		assert.Equal(
+			t,
+			test.expected,
+			actual,
+			"Node accessor test expects a different value for: %s",
+			explanation,
+		)
+
+		if test.expectedError != "" {
+			if assert.Error(
+				t,
+				n.Err(),
+				"Node accessor test expects an error for: %s",
+				explanation,
+			) {
+				assert.Contains(
+					t,
+					n.Err().Error(),
+					test.expectedError,
+					"Node accessor test expects a"+
+						" different error for: %s",
+					explanation,
+				)
+			}
+		} else {
+			assert.NoError(
+				t,
+				n.Err(),
+				"Node accessor test expects no error for: %s",
+				explanation,
+			)
+		}
+	}
+}
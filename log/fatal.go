@@ -0,0 +1,91 @@
+package log
+
+import (
+	"fmt"
+	"os"
+	"runtime/debug"
+)
+
+var osExit = os.Exit
+var debugStack = debug.Stack
+
+// Fatal sends a log message with priority Crit, flushes the
+// syslogger.Syslogger of the Logger so that the message isn't lost, and then
+// exits the program with a status of 1.
+func (l *Logger) Fatal(m interface{}) {
+	_ = l.Crit(m)
+	_ = l.Flush()
+	osExit(1)
+}
+
+// Fatalf is like Fatal, but with the message formatted as by fmt.Sprintf.
+func (l *Logger) Fatalf(format string, a ...interface{}) {
+	l.Fatal(fmt.Sprintf(format, a...))
+}
+
+// Panic sends a log message with priority Crit, flushes the
+// syslogger.Syslogger of the Logger, and then panics with the message.
+func (l *Logger) Panic(m interface{}) {
+	_ = l.Crit(m)
+	_ = l.Flush()
+	panic(m)
+}
+
+// Panicf is like Panic, but with the message formatted as by fmt.Sprintf.
+func (l *Logger) Panicf(format string, a ...interface{}) {
+	l.Panic(fmt.Sprintf(format, a...))
+}
+
+// RecoverAndLog is meant to be deferred, and when the goroutine is panicking
+// it sends a log message with priority Crit containing the panic value and a
+// stack trace, flushes the syslogger.Syslogger of the Logger, and then panics
+// again with the same value.
+func (l *Logger) RecoverAndLog() {
+	if r := recover(); r != nil {
+		l.logPanic(r)
+	}
+}
+
+func (l *Logger) logPanic(r interface{}) {
+	_ = l.Crit(fmt.Sprintf("panic: %v\n\n%s", r, debugStack()))
+	_ = l.Flush()
+	panic(r)
+}
+
+// Fatal sends a log message with priority Crit to the global
+// syslogger.Syslogger, flushes it so that the message isn't lost, and then
+// exits the program with a status of 1.
+func Fatal(m interface{}) {
+	std.Fatal(m)
+}
+
+// Fatalf is like Fatal, but with the message formatted as by fmt.Sprintf.
+func Fatalf(format string, a ...interface{}) {
+	std.Fatalf(format, a...)
+}
+
+// Panic sends a log message with priority Crit to the global
+// syslogger.Syslogger, flushes it, and then panics with the message.
+func Panic(m interface{}) {
+	std.Panic(m)
+}
+
+// Panicf is like Panic, but with the message formatted as by fmt.Sprintf.
+func Panicf(format string, a ...interface{}) {
+	std.Panicf(format, a...)
+}
+
+// RecoverAndLog is meant to be deferred, and when the goroutine is panicking
+// it sends a log message with priority Crit containing the panic value and a
+// stack trace to the global syslogger.Syslogger, flushes it, and then panics
+// again with the same value.
+//
+//	func main() {
+//		defer log.RecoverAndLog()
+//		...
+//	}
+func RecoverAndLog() {
+	if r := recover(); r != nil {
+		std.logPanic(r)
+	}
+}
@@ -0,0 +1,197 @@
+package log
+
+import (
+	"testing"
+
+	"github.com/proidiot/gone/log/pri"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFatal(t *testing.T) {
+	origOsExit := osExit
+	defer func() {
+		osExit = origOsExit
+	}()
+
+	var codes []int
+	osExit = func(code int) {
+		codes = append(codes, code)
+	}
+
+	type testCase struct {
+		callFunc        func()
+		expectedMsg     interface{}
+		expectedPanic   interface{}
+		expectedCodes   []int
+		expectedCalls   []string
+		expectedPanics  bool
+		expectedPrefix  string
+		expectedStacked bool
+	}
+
+	tests := map[string]testCase{
+		"Fatal": {
+			callFunc: func() {
+				Fatal("fatal message")
+			},
+			expectedMsg:   "fatal message",
+			expectedCodes: []int{1},
+			expectedCalls: []string{"syslog", "flush"},
+		},
+		"Fatalf": {
+			callFunc: func() {
+				Fatalf("fatal %d", 42)
+			},
+			expectedMsg:   "fatal 42",
+			expectedCodes: []int{1},
+			expectedCalls: []string{"syslog", "flush"},
+		},
+		"Panic": {
+			callFunc: func() {
+				Panic("panic message")
+			},
+			expectedMsg:    "panic message",
+			expectedPanics: true,
+			expectedPanic:  "panic message",
+			expectedCalls:  []string{"syslog", "flush"},
+		},
+		"Panicf": {
+			callFunc: func() {
+				Panicf("panic %d", 42)
+			},
+			expectedMsg:    "panic 42",
+			expectedPanics: true,
+			expectedPanic:  "panic 42",
+			expectedCalls:  []string{"syslog", "flush"},
+		},
+		"RecoverAndLog": {
+			callFunc: func() {
+				defer RecoverAndLog()
+				panic("recovered message")
+			},
+			expectedPrefix:  "panic: recovered message\n\n",
+			expectedStacked: true,
+			expectedPanics:  true,
+			expectedPanic:   "recovered message",
+			expectedCalls:   []string{"syslog", "flush"},
+		},
+		"RecoverAndLog without panic": {
+			callFunc: func() {
+				defer RecoverAndLog()
+			},
+		},
+	}
+
+	for explanation, test := range tests {
+		codes = nil
+		ls := &lifecycleSyslogger{}
+		SetSyslogger(ls)
+
+		if test.expectedPanics {
+			assert.PanicsWithValue(
+				t,
+				test.expectedPanic,
+				test.callFunc,
+				"Fatal test expects a panic for: %s",
+				explanation,
+			)
+		} else {
+			assert.NotPanics(
+				t,
+				test.callFunc,
+				"Fatal test expects no panic for: %s",
+				explanation,
+			)
+		}
+
+		assert.Equal(
+			t,
+			test.expectedCodes,
+			codes,
+			"Fatal test expects different exit codes for: %s",
+			explanation,
+		)
+		assert.Equal(
+			t,
+			test.expectedCalls,
+			ls.Calls,
+			"Fatal test expects different calls for: %s",
+			explanation,
+		)
+
+		if ls.Calls == nil {
+			continue
+		}
+
+		assert.Equal(
+			t,
+			pri.Crit,
+			ls.LastPri,
+			"Fatal test expects a different priority for: %s",
+			explanation,
+		)
+
+		if test.expectedStacked {
+			msg, _ := ls.LastMsg.(string)
+			assert.Contains(
+				t,
+				msg,
+				test.expectedPrefix,
+				"Fatal test expects the panic value for: %s",
+				explanation,
+			)
+			assert.Contains(
+				t,
+				msg,
+				"goroutine",
+				"Fatal test expects a stack trace for: %s",
+				explanation,
+			)
+		} else {
+			assert.Equal(
+				t,
+				test.expectedMsg,
+				ls.LastMsg,
+				"Fatal test expects a different message"+
+					" for: %s",
+				explanation,
+			)
+		}
+	}
+}
+
+func TestLoggerRecoverAndLog(t *testing.T) {
+	origDebugStack := debugStack
+	defer func() {
+		debugStack = origDebugStack
+	}()
+
+	debugStack = func() []byte {
+		return []byte("stack")
+	}
+
+	ls := &lifecycleSyslogger{}
+	l := New(ls)
+
+	assert.PanicsWithValue(
+		t,
+		"message",
+		func() {
+			defer l.RecoverAndLog()
+			panic("message")
+		},
+		"Logger RecoverAndLog test expects the panic to continue.",
+	)
+	assert.Equal(
+		t,
+		"panic: message\n\nstack",
+		ls.LastMsg,
+		"Logger RecoverAndLog test expects a different message.",
+	)
+	assert.Equal(
+		t,
+		[]string{"syslog", "flush"},
+		ls.Calls,
+		"Logger RecoverAndLog test expects the message to be flushed.",
+	)
+}
@@ -1,9 +1,16 @@
 package log
 
 import (
+	"sync"
+	"sync/atomic"
+	"time"
+
 	"github.com/proidiot/gone/errors"
+	"github.com/proidiot/gone/log/config"
+	"github.com/proidiot/gone/log/mask"
 	"github.com/proidiot/gone/log/opt"
 	"github.com/proidiot/gone/log/pri"
+	"github.com/proidiot/gone/log/syslogger"
 )
 
 type testSyslogger struct {
@@ -55,3 +62,158 @@ func (l *limitedSyslogger) Syslog(p pri.Priority, msg interface{}) error {
 	l.LastMsg = msg
 	return l.triggerError()
 }
+
+type blockingSyslogger struct {
+	Started chan struct{}
+	Release chan struct{}
+	Closed  bool
+	x       sync.Mutex
+}
+
+func (b *blockingSyslogger) Syslog(p pri.Priority, msg interface{}) error {
+	close(b.Started)
+	<-b.Release
+	return nil
+}
+
+func (b *blockingSyslogger) Close() error {
+	b.x.Lock()
+	defer b.x.Unlock()
+	b.Closed = true
+	return nil
+}
+
+func (b *blockingSyslogger) IsClosed() bool {
+	b.x.Lock()
+	defer b.x.Unlock()
+	return b.Closed
+}
+
+type namedSyslogger struct {
+	Name   string
+	Closed bool
+}
+
+func (n *namedSyslogger) Syslog(p pri.Priority, msg interface{}) error {
+	return nil
+}
+
+func (n *namedSyslogger) Close() error {
+	n.Closed = true
+	return nil
+}
+
+func init() {
+	config.Register(
+		"named",
+		func(n *config.Node) (syslogger.Syslogger, error) {
+			return &namedSyslogger{
+				Name: n.String("name"),
+			}, nil
+		},
+	)
+}
+
+func currentSyslogger() syslogger.Syslogger {
+	g := acquire()
+	defer g.release()
+	return g.s
+}
+
+type maskSyslogger struct {
+	Masks []mask.Mask
+	x     sync.Mutex
+}
+
+func (m *maskSyslogger) Syslog(p pri.Priority, msg interface{}) error {
+	return nil
+}
+
+func (m *maskSyslogger) SetLogMask(lm mask.Mask) mask.Mask {
+	prev := m.LogMask()
+	m.x.Lock()
+	defer m.x.Unlock()
+	m.Masks = append(m.Masks, lm)
+	return prev
+}
+
+func (m *maskSyslogger) LogMask() mask.Mask {
+	m.x.Lock()
+	defer m.x.Unlock()
+	if len(m.Masks) == 0 {
+		return mask.Mask(0xFF)
+	}
+	return m.Masks[len(m.Masks)-1]
+}
+
+type timedMaskSyslogger struct {
+	maskSyslogger
+	Durations []time.Duration
+}
+
+func (t *timedMaskSyslogger) SetLogMaskFor(
+	m mask.Mask,
+	d time.Duration,
+) error {
+	t.Durations = append(t.Durations, d)
+	t.SetLogMask(m)
+	return nil
+}
+
+func (t *timedMaskSyslogger) Ident() string {
+	return "admin"
+}
+
+func (t *timedMaskSyslogger) Options() opt.Option {
+	return opt.Pid
+}
+
+func (t *timedMaskSyslogger) Facility() pri.Priority {
+	return pri.Local0
+}
+
+type closeCheckSyslogger struct {
+	closed atomic.Bool
+}
+
+func (c *closeCheckSyslogger) Syslog(p pri.Priority, msg interface{}) error {
+	if c.closed.Load() {
+		return errors.New(
+			"Syslog called on a closed closeCheckSyslogger",
+		)
+	}
+
+	return nil
+}
+
+func (c *closeCheckSyslogger) Close() error {
+	c.closed.Store(true)
+	return nil
+}
+
+type lifecycleSyslogger struct {
+	LastPri pri.Priority
+	LastMsg interface{}
+	Calls   []string
+	Block   chan struct{}
+}
+
+func (l *lifecycleSyslogger) Syslog(p pri.Priority, msg interface{}) error {
+	l.LastPri = p
+	l.LastMsg = msg
+	l.Calls = append(l.Calls, "syslog")
+	return nil
+}
+
+func (l *lifecycleSyslogger) Flush() error {
+	l.Calls = append(l.Calls, "flush")
+	if l.Block != nil {
+		<-l.Block
+	}
+	return nil
+}
+
+func (l *lifecycleSyslogger) Close() error {
+	l.Calls = append(l.Calls, "close")
+	return nil
+}
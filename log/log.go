@@ -1,18 +1,113 @@
 package log
 
 import (
+	"context"
+	"io"
 	"os"
+	"reflect"
+	"sync"
+	"sync/atomic"
 
 	"github.com/proidiot/gone/errors"
+	"github.com/proidiot/gone/log/config"
 	"github.com/proidiot/gone/log/mask"
 	"github.com/proidiot/gone/log/opt"
 	"github.com/proidiot/gone/log/pri"
 	"github.com/proidiot/gone/log/syslogger"
 )
 
-var log syslogger.Syslogger
+// global is a syslogger.Syslogger which has been set as the global
+// syslogger.Syslogger, along with a count of the calls which are using it.
+type global struct {
+	s        syslogger.Syslogger
+	inflight atomic.Int64
+	retired  atomic.Bool
+	drained  chan struct{}
+	once     sync.Once
+}
+
+var current atomic.Pointer[global]
+
+func newGlobal(s syslogger.Syslogger) *global {
+	return &global{
+		s:       s,
+		drained: make(chan struct{}),
+	}
+}
+
+// acquire gives the current global, which must be released once it is no
+// longer in use.
+func acquire() *global {
+	for {
+		g := current.Load()
+		g.inflight.Add(1)
+
+		// If the global was replaced before the count was increased,
+		// then the swap may not have waited for this call, so the
+		// syslogger.Syslogger might already be closed.
+		if current.Load() == g {
+			return g
+		}
+
+		g.release()
+	}
+}
+
+func (g *global) release() {
+	if g.inflight.Add(-1) == 0 && g.retired.Load() {
+		g.once.Do(func() {
+			close(g.drained)
+		})
+	}
+}
+
+// drain waits for every call which is using a global that has been replaced to
+// release it.
+func (g *global) drain() {
+	g.retired.Store(true)
+	if g.inflight.Load() == 0 {
+		g.once.Do(func() {
+			close(g.drained)
+		})
+	}
+
+	<-g.drained
+}
+
+// swap replaces the global syslogger.Syslogger, waits for every call which is
+// still using the previous one to return, and then closes the previous one (if
+// it has a Close function and isn't the same as the new one).
+func swap(s syslogger.Syslogger) error {
+	old := current.Swap(newGlobal(s))
+
+	if old == nil || same(old.s, s) {
+		return nil
+	}
+
+	old.drain()
+
+	if c, ok := old.s.(io.Closer); ok {
+		return c.Close()
+	}
+
+	return nil
+}
+
+// same indicates whether two syslogger.Sysloggers are the same, without
+// panicking if they can't be compared.
+func same(a syslogger.Syslogger, b syslogger.Syslogger) bool {
+	t := reflect.TypeOf(a)
+	return t != nil && t == reflect.TypeOf(b) && t.Comparable() && a == b
+}
 
 func init() {
+	if c, e := config.GetFromEnv(); e != nil {
+		panic(e)
+	} else if c != nil {
+		current.Store(newGlobal(c))
+		return
+	}
+
 	l := &syslogger.Posixish{}
 	e := l.Openlog(
 		os.Getenv("LOG_IDENT"),
@@ -22,28 +117,44 @@ func init() {
 	if e != nil {
 		panic(e)
 	}
-	e = l.SetLogMask(mask.GetFromEnv())
-	if e != nil {
-		panic(e)
-	}
+	l.SetLogMask(mask.GetFromEnv())
+	l.SetFacilityMask(mask.GetFacilityFromEnv())
+	l.SetSelector(mask.GetSelectorFromEnv())
 
-	log = l
+	current.Store(newGlobal(l))
 }
 
 // SetSyslogger overwrites the default global syslogger.Syslogger with the one
-// given explicitly.
+// given explicitly. It is safe to call while other goroutines are logging:
+// calls already in progress finish with the previous syslogger.Syslogger, which
+// is then closed (if it has a Close function).
 func SetSyslogger(s syslogger.Syslogger) {
-	log = s
+	// Unlike Reload, there is no way to report an error from closing the
+	// previous syslogger.Syslogger here.
+	_ = swap(s)
 }
 
-// Openlog allows the global syslogger.Syslogger to be reset with certain
-// explicit initialization values.
-func Openlog(ident string, o opt.Option, f pri.Priority) error {
-	type openlogger interface {
-		Openlog(string, opt.Option, pri.Priority) error
-	}
+// globalSyslogger is the syslogger.Syslogger of the default Logger, which
+// passes everything along to the global syslogger.Syslogger.
+type globalSyslogger struct{}
+
+var std = New(globalSyslogger{})
+
+// Default gives the Logger used by the package functions, which always logs to
+// the global syslogger.Syslogger (even after a call to SetSyslogger).
+func Default() *Logger {
+	return std
+}
 
-	ol, ok := log.(openlogger)
+func (globalSyslogger) Openlog(
+	ident string,
+	o opt.Option,
+	f pri.Priority,
+) error {
+	g := acquire()
+	defer g.release()
+
+	ol, ok := g.s.(openlogger)
 	if !ok {
 		return errors.New(
 			"Default global log has been set to a" +
@@ -55,9 +166,38 @@ func Openlog(ident string, o opt.Option, f pri.Priority) error {
 	return ol.Openlog(ident, o, f)
 }
 
+func (globalSyslogger) Syslog(p pri.Priority, msg interface{}) error {
+	g := acquire()
+	defer g.release()
+
+	e := g.s.Syslog(p, msg)
+	count(p, e)
+	return e
+}
+
+func (globalSyslogger) Flush() error {
+	g := acquire()
+	defer g.release()
+
+	return flush(g.s)
+}
+
+func (globalSyslogger) Close() error {
+	g := acquire()
+	defer g.release()
+
+	return closelog(g.s)
+}
+
+// Openlog allows the global syslogger.Syslogger to be reset with certain
+// explicit initialization values.
+func Openlog(ident string, o opt.Option, f pri.Priority) error {
+	return std.Openlog(ident, o, f)
+}
+
 // Syslog allows logs to be written to the global syslogger.Syslogger.
 func Syslog(p pri.Priority, msg interface{}) error {
-	return log.Syslog(p, msg)
+	return std.Syslog(p, msg)
 }
 
 // Closelog ends the log session of the global syslogger.Syslogger. Depending on
@@ -65,83 +205,102 @@ func Syslog(p pri.Priority, msg interface{}) error {
 // all future Syslog calls creating errors, or it could have practically no
 // effect.
 func Closelog() error {
-	type closer interface {
-		Close() error
-	}
+	return std.Closelog()
+}
 
-	cl, ok := log.(closer)
-	if !ok {
-		return errors.New(
-			"Default global log has been set to a" +
-				" syslogger.Syslogger without a Close" +
-				" function.",
-		)
-	}
+// Flush logs any messages which the global syslogger.Syslogger is still holding
+// on to.
+func Flush() error {
+	return std.Flush()
+}
+
+// Shutdown flushes and then closes the global syslogger.Syslogger, giving up
+// with the error of the context.Context if it is done first. It is meant to be
+// called as the program exits, since messages logged afterwards may be lost.
+func Shutdown(ctx context.Context) error {
+	done := make(chan error, 1)
 
-	return cl.Close()
+	go func() {
+		g := acquire()
+		defer g.release()
+
+		err := flush(g.s)
+		if e := closelog(g.s); err == nil {
+			err = e
+		}
+
+		done <- err
+	}()
+
+	select {
+	case e := <-done:
+		return e
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
 
 // Emerg sends a log message with priority Emerg
 func Emerg(m interface{}) error {
-	return Syslog(pri.Emerg, m)
+	return std.Emerg(m)
 }
 
 // Emergency sends a log message with priority Emerg
 func Emergency(m interface{}) error {
-	return Emerg(m)
+	return std.Emergency(m)
 }
 
 // Alert sends a log message with priority Alert
 func Alert(m interface{}) error {
-	return Syslog(pri.Alert, m)
+	return std.Alert(m)
 }
 
 // Crit sends a log message with priority Crit
 func Crit(m interface{}) error {
-	return Syslog(pri.Crit, m)
+	return std.Crit(m)
 }
 
 // Critical sends a log message with priority Crit
 func Critical(m interface{}) error {
-	return Crit(m)
+	return std.Critical(m)
 }
 
 // Err sends a log message with priority Err
 func Err(m interface{}) error {
-	return Syslog(pri.Err, m)
+	return std.Err(m)
 }
 
 // Error sends a log message with priority Err
 func Error(m interface{}) error {
-	return Err(m)
+	return std.Error(m)
 }
 
 // Warning sends a log message with priority Warning
 func Warning(m interface{}) error {
-	return Syslog(pri.Warning, m)
+	return std.Warning(m)
 }
 
 // Warn sends a log message with priority Warning
 func Warn(m interface{}) error {
-	return Warning(m)
+	return std.Warn(m)
 }
 
 // Notice sends a log message with priority Notice
 func Notice(m interface{}) error {
-	return Syslog(pri.Notice, m)
+	return std.Notice(m)
 }
 
 // Info sends a log message with priority Info
 func Info(m interface{}) error {
-	return Syslog(pri.Info, m)
+	return std.Info(m)
 }
 
 // Information sends a log message with priority Info
 func Information(m interface{}) error {
-	return Info(m)
+	return std.Information(m)
 }
 
 // Debug sends a log message with priority Debug
 func Debug(m interface{}) error {
-	return Syslog(pri.Debug, m)
+	return std.Debug(m)
 }
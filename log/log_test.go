@@ -1,10 +1,16 @@
 package log
 
 import (
+	"context"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/proidiot/gone/errors"
+	"github.com/proidiot/gone/log/mask"
 	"github.com/proidiot/gone/log/opt"
 	"github.com/proidiot/gone/log/pri"
+	"github.com/proidiot/gone/log/syslogger"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -212,3 +218,151 @@ func TestSyslogWrappers(t *testing.T) {
 		}
 	}
 }
+
+func TestSetSysloggerWhileLogging(t *testing.T) {
+	SetSyslogger(new(closeCheckSyslogger))
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 4)
+	stop := make(chan struct{})
+
+	for i := 0; i < cap(errs); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+
+				if e := Info("message"); e != nil {
+					errs <- e
+					return
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < 1000; i++ {
+		SetSyslogger(new(closeCheckSyslogger))
+	}
+
+	close(stop)
+	wg.Wait()
+	close(errs)
+
+	for e := range errs {
+		assert.NoError(
+			t,
+			e,
+			"SetSyslogger test expects no call to use a closed"+
+				" syslogger.",
+		)
+	}
+}
+
+// benchmarkPosixish sets a Posixish as the global syslogger.Syslogger, which
+// masks LOG_INFO so that the benchmarks measure the cost of getting a message
+// to the point of being written rather than the cost of writing it.
+func benchmarkPosixish(b *testing.B) {
+	px := &syslogger.Posixish{}
+	if e := px.Openlog("benchmark", opt.ODelay, pri.User); e != nil {
+		b.Fatal(e)
+	}
+	px.SetLogMask(mask.UpTo(pri.Notice))
+	SetSyslogger(px)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+}
+
+func BenchmarkInfo(b *testing.B) {
+	benchmarkPosixish(b)
+
+	for i := 0; i < b.N; i++ {
+		_ = Info("benchmark")
+	}
+}
+
+func BenchmarkInfoParallel(b *testing.B) {
+	benchmarkPosixish(b)
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = Info("benchmark")
+		}
+	})
+}
+
+func TestShutdown(t *testing.T) {
+	type testCase struct {
+		inputSyslogger syslogger.Syslogger
+		inputBlock     bool
+		expectedError  error
+		expectedCalls  []string
+	}
+
+	tests := map[string]testCase{
+		"flush then close": {
+			inputSyslogger: &lifecycleSyslogger{},
+			expectedCalls:  []string{"flush", "close"},
+		},
+		"deadline": {
+			inputSyslogger: &lifecycleSyslogger{
+				Block: make(chan struct{}),
+			},
+			inputBlock:    true,
+			expectedError: context.DeadlineExceeded,
+		},
+		"nothing to close": {
+			inputSyslogger: new(limitedSyslogger),
+		},
+		"close error": {
+			inputSyslogger: &testSyslogger{TriggerError: true},
+			expectedError: errors.New(
+				"Artificial error triggered in testSyslogger",
+			),
+		},
+	}
+
+	for explanation, test := range tests {
+		SetSyslogger(test.inputSyslogger)
+
+		timeout := time.Second
+		if test.inputBlock {
+			timeout = 10 * time.Millisecond
+		}
+		ctx, cancel := context.WithTimeout(
+			context.Background(),
+			timeout,
+		)
+
+		e := Shutdown(ctx)
+		cancel()
+
+		assert.Equal(
+			t,
+			test.expectedError,
+			e,
+			"Shutdown test expects a different error for: %s",
+			explanation,
+		)
+
+		if l, ok := test.inputSyslogger.(*lifecycleSyslogger); ok {
+			if test.inputBlock {
+				close(l.Block)
+				continue
+			}
+
+			assert.Equal(
+				t,
+				test.expectedCalls,
+				l.Calls,
+				"Shutdown test expects different calls for: %s",
+				explanation,
+			)
+		}
+	}
+}
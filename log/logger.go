@@ -0,0 +1,142 @@
+package log
+
+import (
+	"io"
+
+	"github.com/proidiot/gone/errors"
+	"github.com/proidiot/gone/log/opt"
+	"github.com/proidiot/gone/log/pri"
+	"github.com/proidiot/gone/log/syslogger"
+)
+
+// Logger sends log messages to a syslogger.Syslogger, with the same functions
+// that the package provides for the global syslogger.Syslogger. A library
+// which accepts a *Logger can then be given Default (or a Logger for any other
+// syslogger.Syslogger) by the program using it.
+type Logger struct {
+	Syslogger syslogger.Syslogger
+}
+
+type openlogger interface {
+	Openlog(string, opt.Option, pri.Priority) error
+}
+
+// New creates a Logger which sends log messages to the given
+// syslogger.Syslogger.
+func New(s syslogger.Syslogger) *Logger {
+	return &Logger{
+		Syslogger: s,
+	}
+}
+
+// Openlog allows the syslogger.Syslogger of the Logger to be reset with certain
+// explicit initialization values.
+func (l *Logger) Openlog(ident string, o opt.Option, f pri.Priority) error {
+	ol, ok := l.Syslogger.(openlogger)
+	if !ok {
+		return errors.New(
+			"The log.Logger has been given a syslogger.Syslogger" +
+				" without an Openlog function.",
+		)
+	}
+
+	return ol.Openlog(ident, o, f)
+}
+
+// Syslog sends a log message to the syslogger.Syslogger of the Logger.
+func (l *Logger) Syslog(p pri.Priority, msg interface{}) error {
+	return l.Syslogger.Syslog(p, msg)
+}
+
+// Closelog ends the log session of the syslogger.Syslogger of the Logger, if it
+// has a Close function.
+func (l *Logger) Closelog() error {
+	return closelog(l.Syslogger)
+}
+
+// Flush logs any messages which the syslogger.Syslogger of the Logger is still
+// holding on to, if it has a Flush function.
+func (l *Logger) Flush() error {
+	return flush(l.Syslogger)
+}
+
+func closelog(s syslogger.Syslogger) error {
+	if c, ok := s.(io.Closer); ok {
+		return c.Close()
+	}
+
+	return nil
+}
+
+func flush(s syslogger.Syslogger) error {
+	if f, ok := s.(syslogger.Flusher); ok {
+		return f.Flush()
+	}
+
+	return nil
+}
+
+// Emerg sends a log message with priority Emerg
+func (l *Logger) Emerg(m interface{}) error {
+	return l.Syslog(pri.Emerg, m)
+}
+
+// Emergency sends a log message with priority Emerg
+func (l *Logger) Emergency(m interface{}) error {
+	return l.Emerg(m)
+}
+
+// Alert sends a log message with priority Alert
+func (l *Logger) Alert(m interface{}) error {
+	return l.Syslog(pri.Alert, m)
+}
+
+// Crit sends a log message with priority Crit
+func (l *Logger) Crit(m interface{}) error {
+	return l.Syslog(pri.Crit, m)
+}
+
+// Critical sends a log message with priority Crit
+func (l *Logger) Critical(m interface{}) error {
+	return l.Crit(m)
+}
+
+// Err sends a log message with priority Err
+func (l *Logger) Err(m interface{}) error {
+	return l.Syslog(pri.Err, m)
+}
+
+// Error sends a log message with priority Err
+func (l *Logger) Error(m interface{}) error {
+	return l.Err(m)
+}
+
+// Warning sends a log message with priority Warning
+func (l *Logger) Warning(m interface{}) error {
+	return l.Syslog(pri.Warning, m)
+}
+
+// Warn sends a log message with priority Warning
+func (l *Logger) Warn(m interface{}) error {
+	return l.Warning(m)
+}
+
+// Notice sends a log message with priority Notice
+func (l *Logger) Notice(m interface{}) error {
+	return l.Syslog(pri.Notice, m)
+}
+
+// Info sends a log message with priority Info
+func (l *Logger) Info(m interface{}) error {
+	return l.Syslog(pri.Info, m)
+}
+
+// Information sends a log message with priority Info
+func (l *Logger) Information(m interface{}) error {
+	return l.Info(m)
+}
+
+// Debug sends a log message with priority Debug
+func (l *Logger) Debug(m interface{}) error {
+	return l.Syslog(pri.Debug, m)
+}
@@ -0,0 +1,248 @@
+package log
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/proidiot/gone/log/opt"
+	"github.com/proidiot/gone/log/pri"
+	"github.com/proidiot/gone/log/syslogger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoggerOpenlog(t *testing.T) {
+	type testCase struct {
+		useLimitedSyslogger bool
+		causeError          bool
+		expectedError       bool
+		expectedCloseError  bool
+	}
+
+	tests := map[string]testCase{
+		"nil values": {
+			expectedError: false,
+		},
+		"syslogger error": {
+			causeError:         true,
+			expectedError:      true,
+			expectedCloseError: true,
+		},
+		"limited syslogger": {
+			useLimitedSyslogger: true,
+			expectedError:       true,
+		},
+	}
+
+	for explanation, test := range tests {
+		var l *Logger
+		if test.useLimitedSyslogger {
+			l = New(new(limitedSyslogger))
+		} else {
+			l = New(&testSyslogger{TriggerError: test.causeError})
+		}
+
+		actualError := l.Openlog("", opt.Option(0x0), pri.Priority(0x0))
+		closeError := l.Closelog()
+
+		if test.expectedError {
+			assert.Errorf(
+				t,
+				actualError,
+				"Logger Openlog test expects an error for: %s",
+				explanation,
+			)
+		} else {
+			assert.NoError(
+				t,
+				actualError,
+				"Logger Openlog test expects no error for: %s",
+				explanation,
+			)
+		}
+
+		assert.Equal(
+			t,
+			test.expectedCloseError,
+			closeError != nil,
+			"Logger Openlog test expects a different Closelog"+
+				" result for: %s",
+			explanation,
+		)
+	}
+}
+
+func TestLoggerSyslogWrappers(t *testing.T) {
+	type testCase struct {
+		callFunc         func(*Logger, interface{}) error
+		expectedPriority pri.Priority
+	}
+
+	tests := map[string]testCase{
+		"emerg": {
+			callFunc:         (*Logger).Emerg,
+			expectedPriority: pri.Emerg,
+		},
+		"emergency": {
+			callFunc:         (*Logger).Emergency,
+			expectedPriority: pri.Emerg,
+		},
+		"alert": {
+			callFunc:         (*Logger).Alert,
+			expectedPriority: pri.Alert,
+		},
+		"crit": {
+			callFunc:         (*Logger).Crit,
+			expectedPriority: pri.Crit,
+		},
+		"critical": {
+			callFunc:         (*Logger).Critical,
+			expectedPriority: pri.Crit,
+		},
+		"err": {
+			callFunc:         (*Logger).Err,
+			expectedPriority: pri.Err,
+		},
+		"error": {
+			callFunc:         (*Logger).Error,
+			expectedPriority: pri.Err,
+		},
+		"warning": {
+			callFunc:         (*Logger).Warning,
+			expectedPriority: pri.Warning,
+		},
+		"warn": {
+			callFunc:         (*Logger).Warn,
+			expectedPriority: pri.Warning,
+		},
+		"notice": {
+			callFunc:         (*Logger).Notice,
+			expectedPriority: pri.Notice,
+		},
+		"info": {
+			callFunc:         (*Logger).Info,
+			expectedPriority: pri.Info,
+		},
+		"information": {
+			callFunc:         (*Logger).Information,
+			expectedPriority: pri.Info,
+		},
+		"debug": {
+			callFunc:         (*Logger).Debug,
+			expectedPriority: pri.Debug,
+		},
+	}
+
+	for explanation, test := range tests {
+		s := new(testSyslogger)
+		l := New(s)
+
+		actualError := test.callFunc(l, explanation)
+		assert.NoError(
+			t,
+			actualError,
+			"Logger wrapper test expects no error for: %s",
+			explanation,
+		)
+
+		assert.Equal(
+			t,
+			test.expectedPriority,
+			s.LastPri,
+			"Logger wrapper test expects the priority to match"+
+				" for: %s",
+			explanation,
+		)
+
+		assert.Equal(
+			t,
+			explanation,
+			s.LastMsg,
+			"Logger wrapper test expects the message to match"+
+				" for: %s",
+			explanation,
+		)
+	}
+}
+
+func TestDefault(t *testing.T) {
+	first := new(testSyslogger)
+	SetSyslogger(first)
+
+	l := Default()
+	assert.NoError(t, l.Notice("first"), "Error during Notice")
+
+	second := new(testSyslogger)
+	SetSyslogger(second)
+	assert.NoError(t, l.Info("second"), "Error during Info")
+
+	assert.Equal(
+		t,
+		"first",
+		first.LastMsg,
+		"Default test expects the message to reach the first global"+
+			" syslogger.",
+	)
+	assert.Equal(
+		t,
+		"second",
+		second.LastMsg,
+		"Default test expects the message to reach the global"+
+			" syslogger set later.",
+	)
+}
+
+func TestLoggerCaller(t *testing.T) {
+	type testCase struct {
+		callFunc func(syslogger.Syslogger) error
+	}
+
+	tests := map[string]testCase{
+		"Logger": {
+			callFunc: func(s syslogger.Syslogger) error {
+				return New(s).Info("message")
+			},
+		},
+		"Logger Syslog": {
+			callFunc: func(s syslogger.Syslogger) error {
+				return New(s).Syslog(pri.Info, "message")
+			},
+		},
+		"package function": {
+			callFunc: func(s syslogger.Syslogger) error {
+				SetSyslogger(s)
+				return Info("message")
+			},
+		},
+		"package function through Default": {
+			callFunc: func(s syslogger.Syslogger) error {
+				SetSyslogger(s)
+				return Default().Information("message")
+			},
+		},
+	}
+
+	for explanation, test := range tests {
+		var b bytes.Buffer
+		s := &syslogger.Rfc3164{
+			Syslogger: &syslogger.Writer{Writer: &b},
+			Ident:     "app",
+			Caller:    true,
+		}
+
+		assert.NoError(
+			t,
+			test.callFunc(s),
+			"Logger caller test expects no error for: %s",
+			explanation,
+		)
+		assert.Regexp(
+			t,
+			` app: \[logger_test\.go:\d+`+
+				` log\.TestLoggerCaller\.func\d+\] message$`,
+			b.String(),
+			"Logger caller test expects the location of the caller"+
+				" for: %s",
+			explanation,
+		)
+	}
+}
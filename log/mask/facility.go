@@ -0,0 +1,120 @@
+package mask
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/proidiot/gone/log/pri"
+)
+
+// Facility represents a log facility mask, with one bit for each of the 24 log
+// facilities from pri.Kern through pri.Local7. It is analogous to Mask, but it
+// allows messages to be filtered based on their facility rather than their
+// severity.
+type Facility uint32
+
+// AllFacilities is a Facility that doesn't mask any legitimate log facility.
+const AllFacilities Facility = 0xFFFFFF
+
+// FacilityOf creates a Facility that only allows messages with the facility
+// component of the given pri.Priority to be processed. These values can be
+// combined with a bitwise or.
+func FacilityOf(p pri.Priority) Facility {
+	return Facility(1) << (p.Facility() >> 3)
+}
+
+// Masked indicates whether a log message with the given pri.Priority should be
+// masked (i.e. hidden) by this Facility.
+func (f Facility) Masked(p pri.Priority) bool {
+	return (f & FacilityOf(p)) == 0
+}
+
+// String creates a string representation of the Facility.
+func (f Facility) String() string {
+	if f == AllFacilities {
+		return "LOG_FACILITY_MASK(*)"
+	} else if f == 0x00 {
+		return "LOG_FACILITY_MASK(0x0)"
+	}
+
+	masked := []string{}
+	for p := 0; p < 32; p++ {
+		if (f & (Facility(1) << uint(p))) != 0 {
+			name := facilityName(pri.Priority(p << 3))
+			masked = append(masked, name)
+		}
+	}
+	return fmt.Sprintf("LOG_FACILITY_MASK(%s)", strings.Join(masked, "|"))
+}
+
+// GetFacilityFromEnv gives the Facility indicated by the LOG_FACILITY_MASK
+// environment variable (or else the default Facility, which is
+// AllFacilities). The variable holds either a bitwise or of facility names
+// (such as "LOG_AUTH|LOG_LOCAL0") or "*" to indicate all facilities.
+func GetFacilityFromEnv() Facility {
+	vals, set := os.LookupEnv("LOG_FACILITY_MASK")
+	if !set || vals == "*" {
+		return AllFacilities
+	}
+
+	f, e := ParseFacility(vals)
+	if e != nil {
+		// An invalid facility value was given, abort to default.
+		return AllFacilities
+	}
+
+	return f
+}
+
+// ParseFacility gives the Facility described by a string, which may either have
+// the form given by String (such as "LOG_FACILITY_MASK(LOG_AUTH|LOG_LOCAL0)")
+// or simply be a bitwise or of facility names (such as "LOG_AUTH|LOG_LOCAL0").
+// The string "*" describes AllFacilities.
+func ParseFacility(s string) (Facility, error) {
+	s = strings.TrimSpace(s)
+	if inner, ok := unwrap(s, "LOG_FACILITY_MASK"); ok {
+		if inner == "0x0" {
+			return Facility(0), nil
+		}
+
+		s = inner
+	}
+
+	if s == "*" {
+		return AllFacilities, nil
+	}
+
+	f := Facility(0)
+
+facilityLoop:
+	for _, val := range strings.Split(s, "|") {
+		for p := pri.Kern; p <= pri.Local7; p += pri.Priority(1 << 3) {
+			if val == facilityName(p) {
+				f |= FacilityOf(p)
+				continue facilityLoop
+			}
+		}
+
+		return Facility(0), fmt.Errorf(
+			"A facility mask must be a bitwise or of facility"+
+				" names such as LOG_AUTH, but the facility"+
+				" mask %q contains %q.",
+			s,
+			val,
+		)
+	}
+
+	return f, nil
+}
+
+// facilityName gives the name of the facility component of a pri.Priority. This
+// is needed since pri.Kern is zero, so its pri.Priority string would instead be
+// the name of a severity.
+func facilityName(p pri.Priority) string {
+	if p.Facility() == pri.Kern {
+		return "LOG_KERN"
+	}
+
+	return p.Facility().String()
+}
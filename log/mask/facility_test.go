@@ -0,0 +1,256 @@
+package mask
+
+import (
+	"os"
+	"testing"
+
+	"github.com/proidiot/gone/log/pri"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFacilityOf(t *testing.T) {
+	type testCase struct {
+		input    pri.Priority
+		expected Facility
+	}
+
+	tests := map[string]testCase{
+		"kern gives lowest bit": {
+			input:    pri.Kern,
+			expected: Facility(0x01),
+		},
+		"local7 gives highest bit": {
+			input:    pri.Local7,
+			expected: Facility(0x800000),
+		},
+		"specific input gives specific output": {
+			input:    pri.Auth,
+			expected: Facility(0x10),
+		},
+		"severity does not effect output": {
+			input:    pri.Auth | pri.Debug,
+			expected: Facility(0x10),
+		},
+	}
+
+	for explanation, test := range tests {
+		actual := FacilityOf(test.input)
+
+		assert.Equal(
+			t,
+			test.expected,
+			actual,
+			"FacilityOf test failed for: %s",
+			explanation,
+		)
+	}
+}
+
+func TestFacilityMasked(t *testing.T) {
+	type testCase struct {
+		inputMask Facility
+		inputPri  pri.Priority
+		expected  bool
+	}
+
+	tests := map[string]testCase{
+		"zero mask hides everything": {
+			inputMask: Facility(0x0),
+			inputPri:  pri.User,
+			expected:  true,
+		},
+		"full mask hides nothing": {
+			inputMask: AllFacilities,
+			inputPri:  pri.Local7 | pri.Debug,
+			expected:  false,
+		},
+		"specific mask allows specific facility": {
+			inputMask: FacilityOf(pri.Auth),
+			inputPri:  pri.Auth | pri.Err,
+			expected:  false,
+		},
+		"specific mask hides other facility": {
+			inputMask: FacilityOf(pri.Auth),
+			inputPri:  pri.Authpriv | pri.Err,
+			expected:  true,
+		},
+		"compound mask allows constituents": {
+			inputMask: FacilityOf(pri.Mail) |
+				FacilityOf(pri.Local0),
+			inputPri: pri.Local0 | pri.Info,
+			expected: false,
+		},
+	}
+
+	for explanation, test := range tests {
+		actual := test.inputMask.Masked(test.inputPri)
+
+		assert.Equal(
+			t,
+			test.expected,
+			actual,
+			"Facility Masked test failed for: %s",
+			explanation,
+		)
+	}
+}
+
+func TestFacilityString(t *testing.T) {
+	type testCase struct {
+		input    Facility
+		expected string
+	}
+
+	tests := map[string]testCase{
+		"zero value": {
+			input:    Facility(0x00),
+			expected: "LOG_FACILITY_MASK(0x0)",
+		},
+		"full value": {
+			input:    AllFacilities,
+			expected: "LOG_FACILITY_MASK(*)",
+		},
+		"kern value": {
+			input:    FacilityOf(pri.Kern),
+			expected: "LOG_FACILITY_MASK(LOG_KERN)",
+		},
+		"multi value": {
+			input:    FacilityOf(pri.Auth) | FacilityOf(pri.Local0),
+			expected: "LOG_FACILITY_MASK(LOG_AUTH|LOG_LOCAL0)",
+		},
+	}
+
+	for explanation, test := range tests {
+		actual := test.input.String()
+
+		assert.Equal(
+			t,
+			test.expected,
+			actual,
+			"Facility String test failed for: %s",
+			explanation,
+		)
+	}
+}
+
+func TestFacilityGetFromEnv(t *testing.T) {
+	type testCase struct {
+		input    *string
+		expected Facility
+	}
+
+	str := func(s string) *string {
+		return &s
+	}
+
+	tests := map[string]testCase{
+		"not set": {
+			input:    nil,
+			expected: AllFacilities,
+		},
+		"all set": {
+			input:    str("*"),
+			expected: AllFacilities,
+		},
+		"single set": {
+			input:    str("LOG_KERN"),
+			expected: FacilityOf(pri.Kern),
+		},
+		"multi set": {
+			input:    str("LOG_AUTH|LOG_LOCAL0"),
+			expected: FacilityOf(pri.Auth) | FacilityOf(pri.Local0),
+		},
+		"bad simple value": {
+			input:    str("LOG_ERR"),
+			expected: AllFacilities,
+		},
+		"bad compound syntax": {
+			input:    str("LOG_AUTH | LOG_LOCAL0"),
+			expected: AllFacilities,
+		},
+	}
+
+	for explanation, test := range tests {
+		e := os.Unsetenv("LOG_FACILITY_MASK")
+		assert.NoError(t, e, "Error during Unsetenv")
+
+		if test.input != nil {
+			e := os.Setenv("LOG_FACILITY_MASK", *test.input)
+			assert.NoError(t, e, "Error during Setenv")
+		}
+
+		actual := GetFacilityFromEnv()
+
+		assert.Equal(
+			t,
+			test.expected,
+			actual,
+			"mask.GetFacilityFromEnv test failed for: %s",
+			explanation,
+		)
+	}
+}
+
+func TestParseFacility(t *testing.T) {
+	type testCase struct {
+		input         string
+		expected      Facility
+		expectedError bool
+	}
+
+	tests := map[string]testCase{
+		"all": {
+			input:    "*",
+			expected: AllFacilities,
+		},
+		"string form of all": {
+			input:    "LOG_FACILITY_MASK(*)",
+			expected: AllFacilities,
+		},
+		"string form of zero": {
+			input:    "LOG_FACILITY_MASK(0x0)",
+			expected: Facility(0x0),
+		},
+		"string form": {
+			input:    "LOG_FACILITY_MASK(LOG_KERN|LOG_MAIL)",
+			expected: FacilityOf(pri.Kern) | FacilityOf(pri.Mail),
+		},
+		"bare list": {
+			input:    "LOG_AUTH|LOG_LOCAL0",
+			expected: FacilityOf(pri.Auth) | FacilityOf(pri.Local0),
+		},
+		"bad value": {
+			input:         "LOG_AUTH|LOG_ERR",
+			expectedError: true,
+		},
+	}
+
+	for explanation, test := range tests {
+		actual, actualError := ParseFacility(test.input)
+
+		if test.expectedError {
+			assert.Error(
+				t,
+				actualError,
+				"mask.ParseFacility test expects an error for:"+
+					" %s",
+				explanation,
+			)
+		} else {
+			assert.NoError(
+				t,
+				actualError,
+				"mask.ParseFacility test expects no error for:"+
+					" %s",
+				explanation,
+			)
+			assert.Equal(
+				t,
+				test.expected,
+				actual,
+				"mask.ParseFacility test failed for: %s",
+				explanation,
+			)
+		}
+	}
+}
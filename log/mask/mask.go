@@ -108,3 +108,66 @@ func GetFromEnv() Mask {
 		return Mask(0xFF)
 	}
 }
+
+// Parse gives the Mask described by a string, which may either have the form
+// given by String (such as "LOG_UPTO(LOG_ERR)" or "LOG_MASK(LOG_ERR|LOG_INFO)")
+// or simply be a bitwise or of severity names (such as "LOG_ERR|LOG_INFO").
+func Parse(s string) (Mask, error) {
+	s = strings.TrimSpace(s)
+
+	if inner, ok := unwrap(s, "LOG_UPTO"); ok {
+		for mask, mstring := range lookup {
+			if inner == mstring {
+				if mask == Debug {
+					return Mask(0xFF), nil
+				}
+
+				return (mask << 1) - 1, nil
+			}
+		}
+
+		return Mask(0), fmt.Errorf(
+			"A LOG_UPTO mask must be given a single severity name,"+
+				" but the mask %q does not have one.",
+			s,
+		)
+	} else if inner, ok := unwrap(s, "LOG_MASK"); ok {
+		if inner == "0x0" {
+			return Mask(0), nil
+		}
+
+		s = inner
+	}
+
+	m := Mask(0)
+
+maskLoop:
+	for _, val := range strings.Split(s, "|") {
+		for mask, mstring := range lookup {
+			if val == mstring {
+				m |= mask
+				continue maskLoop
+			}
+		}
+
+		return Mask(0), fmt.Errorf(
+			"A log mask must be a bitwise or of severity names"+
+				" such as LOG_ERR, but the mask %q contains"+
+				" %q.",
+			s,
+			val,
+		)
+	}
+
+	return m, nil
+}
+
+// unwrap gives the argument of a string of the form "name(arg)", and whether
+// the string had that form.
+func unwrap(s string, name string) (string, bool) {
+	if !strings.HasPrefix(s, name+"(") || !strings.HasSuffix(s, ")") {
+		return "", false
+	}
+
+	return s[len(name)+1 : len(s)-1], true
+}
@@ -236,3 +236,73 @@ func TestMaskGetFromEnv(t *testing.T) {
 		)
 	}
 }
+
+func TestMaskParse(t *testing.T) {
+	type testCase struct {
+		input         string
+		expected      Mask
+		expectedError bool
+	}
+
+	tests := map[string]testCase{
+		"upto": {
+			input:    "LOG_UPTO(LOG_CRIT)",
+			expected: Crit | Alert | Emerg,
+		},
+		"upto debug": {
+			input:    "LOG_UPTO(LOG_DEBUG)",
+			expected: Mask(0xFF),
+		},
+		"mask": {
+			input:    "LOG_MASK(LOG_EMERG|LOG_ERR)",
+			expected: Emerg | Err,
+		},
+		"zero mask": {
+			input:    "LOG_MASK(0x0)",
+			expected: Mask(0x0),
+		},
+		"bare list": {
+			input:    "LOG_ERR|LOG_NOTICE",
+			expected: Err | Notice,
+		},
+		"bad upto": {
+			input:         "LOG_UPTO(LOG_ERR|LOG_INFO)",
+			expectedError: true,
+		},
+		"bad value": {
+			input:         "LOG_ERR|LOG_USER",
+			expectedError: true,
+		},
+		"empty": {
+			input:         "",
+			expectedError: true,
+		},
+	}
+
+	for explanation, test := range tests {
+		actual, actualError := Parse(test.input)
+
+		if test.expectedError {
+			assert.Error(
+				t,
+				actualError,
+				"mask.Parse test expects an error for: %s",
+				explanation,
+			)
+		} else {
+			assert.NoError(
+				t,
+				actualError,
+				"mask.Parse test expects no error for: %s",
+				explanation,
+			)
+			assert.Equal(
+				t,
+				test.expected,
+				actual,
+				"mask.Parse test failed for: %s",
+				explanation,
+			)
+		}
+	}
+}
@@ -0,0 +1,283 @@
+package mask
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/proidiot/gone/errors"
+	"github.com/proidiot/gone/log/pri"
+)
+
+// Selector is a log message filter in the style of the selectors used by
+// syslog.conf and rsyslog.conf, which holds a severity Mask for each of the 24
+// log facilities from pri.Kern through pri.Local7 (indexed by the facility
+// number). The zero value selects nothing.
+type Selector [24]Mask
+
+var selectorFacilities = map[string]pri.Priority{
+	// The security facility is a deprecated synonym of auth.
+	"security": pri.Auth,
+}
+
+var selectorSeverities = map[string]pri.Priority{
+	"emerg":   pri.Emerg,
+	"panic":   pri.Emerg,
+	"alert":   pri.Alert,
+	"crit":    pri.Crit,
+	"err":     pri.Err,
+	"error":   pri.Err,
+	"warning": pri.Warning,
+	"warn":    pri.Warning,
+	"notice":  pri.Notice,
+	"info":    pri.Info,
+	"debug":   pri.Debug,
+}
+
+var selectorSeverityNames = [8]string{
+	"emerg",
+	"alert",
+	"crit",
+	"err",
+	"warning",
+	"notice",
+	"info",
+	"debug",
+}
+
+func init() {
+	for p := pri.Kern; p <= pri.Local7; p += pri.Priority(1 << 3) {
+		name := strings.TrimPrefix(facilityName(p), "LOG_")
+		selectorFacilities[strings.ToLower(name)] = p
+	}
+}
+
+// SelectAll creates a Selector that selects every legitimate log message.
+func SelectAll() Selector {
+	var s Selector
+	for i := range s {
+		s[i] = Mask(0xFF)
+	}
+	return s
+}
+
+// ParseSelector compiles a selector written in the classic syslog.conf syntax,
+// such as "auth,authpriv.*;*.warn;mail.none", into a Selector. The selector
+// is a semicolon separated list of facility.severity pairs which are applied
+// in order, where the facility is a comma separated list of facility names
+// (or "*" for every facility). The severity is either "*" (for every
+// severity), "none" (which unselects anything previously selected for those
+// facilities), or a severity name optionally preceded by a comparison:
+//
+//	sev      sev and anything more severe
+//	>=sev    sev and anything more severe (the same as sev)
+//	>sev     anything more severe than sev
+//	=sev     only sev
+//	<=sev    sev and anything less severe
+//	<sev     anything less severe than sev
+//
+// Any severity other than none may be preceded by "!" in order to unselect the
+// severities it describes rather than selecting them, so "mail.!=info" removes
+// only the info severity of the mail facility from the Selector.
+func ParseSelector(s string) (Selector, error) {
+	var sel Selector
+
+	if strings.TrimSpace(s) == "" {
+		return sel, errors.New(
+			"A selector must have at least one facility.severity" +
+				" pair, but an empty selector was given.",
+		)
+	}
+
+	for _, part := range strings.Split(s, ";") {
+		part = strings.TrimSpace(part)
+
+		i := strings.LastIndex(part, ".")
+		if i < 0 {
+			return sel, fmt.Errorf(
+				"Each part of a selector must have the form"+
+					" facility.severity, but the selector"+
+					" part %q has no period.",
+				part,
+			)
+		}
+
+		m, unselect, e := parseSelectorSeverity(part[i+1:])
+		if e != nil {
+			return sel, e
+		}
+
+		var facilities []int
+		for _, f := range strings.Split(part[:i], ",") {
+			f = strings.TrimSpace(f)
+			if f == "*" {
+				for idx := range sel {
+					facilities = append(facilities, idx)
+				}
+			} else if p, present := selectorFacilities[f]; present {
+				facilities = append(facilities, int(p>>3))
+			} else {
+				return sel, fmt.Errorf(
+					"The facility %q in the selector part"+
+						" %q is not a known facility"+
+						" name.",
+					f,
+					part,
+				)
+			}
+		}
+
+		for _, idx := range facilities {
+			if unselect {
+				sel[idx] &^= m
+			} else {
+				sel[idx] |= m
+			}
+		}
+	}
+
+	return sel, nil
+}
+
+// parseSelectorSeverity gives the Mask described by the severity part of a
+// selector, and whether that Mask should be unselected rather than selected.
+func parseSelectorSeverity(s string) (Mask, bool, error) {
+	orig := s
+	s = strings.TrimSpace(s)
+
+	unselect := strings.HasPrefix(s, "!")
+	s = strings.TrimPrefix(s, "!")
+
+	op := ""
+	for _, o := range []string{"<=", ">=", "=", "<", ">"} {
+		if strings.HasPrefix(s, o) {
+			op = o
+			s = s[len(o):]
+			break
+		}
+	}
+
+	if s == "*" && op == "" {
+		return Mask(0xFF), unselect, nil
+	} else if s == "none" && op == "" && !unselect {
+		return Mask(0xFF), true, nil
+	}
+
+	sev, present := selectorSeverities[s]
+	if !present {
+		return Mask(0), false, fmt.Errorf(
+			"The severity %q in a selector is not a known severity"+
+				" name, optionally preceded by \"!\" and one"+
+				" of \"=\", \"<\", \">\", \"<=\", or \">=\".",
+			orig,
+		)
+	}
+
+	only := Mask(1 << sev)
+	switch op {
+	case "=":
+		return only, unselect, nil
+	case ">":
+		return UpTo(sev) &^ only, unselect, nil
+	case "<":
+		return ^UpTo(sev), unselect, nil
+	case "<=":
+		return ^UpTo(sev) | only, unselect, nil
+	default:
+		return UpTo(sev), unselect, nil
+	}
+}
+
+// Match indicates whether a log message with the given pri.Priority is
+// selected by this Selector.
+func (s Selector) Match(p pri.Priority) bool {
+	idx := int(p.Facility() >> 3)
+	if idx >= len(s) {
+		return false
+	}
+
+	return !s[idx].Masked(p)
+}
+
+// String creates a string representation of the Selector in the same syntax
+// accepted by ParseSelector. Facilities with identical severities are grouped
+// together.
+func (s Selector) String() string {
+	var parts []string
+	done := [len(s)]bool{}
+
+	for i, m := range s {
+		if done[i] || m == 0x00 {
+			continue
+		}
+
+		var facilities []string
+		for j := i; j < len(s); j++ {
+			if s[j] == m {
+				done[j] = true
+				p := pri.Priority(j << 3)
+				name := facilityName(p)
+				facilities = append(
+					facilities,
+					strings.ToLower(name[len("LOG_"):]),
+				)
+			}
+		}
+
+		f := strings.Join(facilities, ",")
+		if len(facilities) == len(s) {
+			f = "*"
+		}
+
+		for _, sev := range selectorSeverityStrings(m) {
+			parts = append(parts, f+"."+sev)
+		}
+	}
+
+	if len(parts) == 0 {
+		return "*.none"
+	}
+
+	return strings.Join(parts, ";")
+}
+
+// selectorSeverityStrings gives the severity parts of the selectors which
+// together describe a Mask.
+func selectorSeverityStrings(m Mask) []string {
+	if m == 0xFF {
+		return []string{"*"}
+	} else if (m & (m + 1)) == 0 {
+		for sev := range selectorSeverityNames {
+			if m == UpTo(pri.Priority(sev)) {
+				return []string{selectorSeverityNames[sev]}
+			}
+		}
+	}
+
+	var res []string
+	for sev, name := range selectorSeverityNames {
+		if (m & (1 << uint(sev))) != 0 {
+			res = append(res, "="+name)
+		}
+	}
+	return res
+}
+
+// GetSelectorFromEnv gives the Selector indicated by the LOG_SELECTOR
+// environment variable (or else the default Selector, which selects
+// everything). The variable holds a selector in the syntax accepted by
+// ParseSelector.
+func GetSelectorFromEnv() Selector {
+	val, set := os.LookupEnv("LOG_SELECTOR")
+	if !set {
+		return SelectAll()
+	}
+
+	s, e := ParseSelector(val)
+	if e != nil {
+		// An invalid selector was given, abort to default.
+		return SelectAll()
+	}
+
+	return s
+}
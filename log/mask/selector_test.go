@@ -0,0 +1,326 @@
+package mask
+
+import (
+	"os"
+	"testing"
+
+	"github.com/proidiot/gone/log/pri"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSelector(t *testing.T) {
+	type testCase struct {
+		input         string
+		expectedError bool
+		selected      []pri.Priority
+		unselected    []pri.Priority
+	}
+
+	tests := map[string]testCase{
+		"empty selector": {
+			input:         "",
+			expectedError: true,
+		},
+		"missing severity": {
+			input:         "mail",
+			expectedError: true,
+		},
+		"unknown facility": {
+			input:         "bogus.info",
+			expectedError: true,
+		},
+		"unknown severity": {
+			input:         "mail.bogus",
+			expectedError: true,
+		},
+		"comparison of everything": {
+			input:         "mail.<*",
+			expectedError: true,
+		},
+		"negated none": {
+			input:         "mail.!none",
+			expectedError: true,
+		},
+		"everything": {
+			input: "*.*",
+			selected: []pri.Priority{
+				pri.Kern | pri.Emerg,
+				pri.Local7 | pri.Debug,
+			},
+		},
+		"plain severity includes more severe": {
+			input: "mail.warn",
+			selected: []pri.Priority{
+				pri.Mail | pri.Warning,
+				pri.Mail | pri.Emerg,
+			},
+			unselected: []pri.Priority{
+				pri.Mail | pri.Notice,
+				pri.User | pri.Emerg,
+			},
+		},
+		"equal only": {
+			input: "mail.=info",
+			selected: []pri.Priority{
+				pri.Mail | pri.Info,
+			},
+			unselected: []pri.Priority{
+				pri.Mail | pri.Err,
+				pri.Mail | pri.Debug,
+			},
+		},
+		"less than": {
+			input: "mail.<notice",
+			selected: []pri.Priority{
+				pri.Mail | pri.Info,
+				pri.Mail | pri.Debug,
+			},
+			unselected: []pri.Priority{
+				pri.Mail | pri.Notice,
+				pri.Mail | pri.Err,
+			},
+		},
+		"less than or equal": {
+			input: "mail.<=notice",
+			selected: []pri.Priority{
+				pri.Mail | pri.Notice,
+				pri.Mail | pri.Debug,
+			},
+			unselected: []pri.Priority{
+				pri.Mail | pri.Warning,
+			},
+		},
+		"greater than": {
+			input: "mail.>crit",
+			selected: []pri.Priority{
+				pri.Mail | pri.Alert,
+			},
+			unselected: []pri.Priority{
+				pri.Mail | pri.Crit,
+			},
+		},
+		"facility list and none": {
+			input: "auth,authpriv.*;*.warn;mail.none",
+			selected: []pri.Priority{
+				pri.Auth | pri.Debug,
+				pri.Authpriv | pri.Info,
+				pri.Local0 | pri.Err,
+			},
+			unselected: []pri.Priority{
+				pri.Mail | pri.Emerg,
+				pri.Local0 | pri.Notice,
+			},
+		},
+		"negation": {
+			input: "*.info;mail.!=info;cron.!err",
+			selected: []pri.Priority{
+				pri.Mail | pri.Notice,
+				pri.Cron | pri.Warning,
+			},
+			unselected: []pri.Priority{
+				pri.Mail | pri.Info,
+				pri.Cron | pri.Err,
+				pri.Cron | pri.Emerg,
+				pri.User | pri.Debug,
+			},
+		},
+		"aliases and whitespace": {
+			input: " security.panic ; user.error ",
+			selected: []pri.Priority{
+				pri.Auth | pri.Emerg,
+				pri.User | pri.Err,
+			},
+			unselected: []pri.Priority{
+				pri.Auth | pri.Alert,
+				pri.User | pri.Warning,
+			},
+		},
+	}
+
+	for explanation, test := range tests {
+		actual, actualError := ParseSelector(test.input)
+
+		if test.expectedError {
+			assert.Error(
+				t,
+				actualError,
+				"ParseSelector test expects an error for: %s",
+				explanation,
+			)
+			continue
+		}
+
+		assert.NoError(
+			t,
+			actualError,
+			"ParseSelector test expects no error for: %s",
+			explanation,
+		)
+
+		for _, p := range test.selected {
+			assert.True(
+				t,
+				actual.Match(p),
+				"ParseSelector test expects %s to be selected"+
+					" for: %s",
+				p,
+				explanation,
+			)
+		}
+
+		for _, p := range test.unselected {
+			assert.False(
+				t,
+				actual.Match(p),
+				"ParseSelector test expects %s to be"+
+					" unselected for: %s",
+				p,
+				explanation,
+			)
+		}
+	}
+}
+
+func TestSelectorMatch(t *testing.T) {
+	type testCase struct {
+		input    Selector
+		inputPri pri.Priority
+		expected bool
+	}
+
+	tests := map[string]testCase{
+		"zero selector matches nothing": {
+			input:    Selector{},
+			inputPri: pri.User | pri.Emerg,
+			expected: false,
+		},
+		"select all matches everything": {
+			input:    SelectAll(),
+			inputPri: pri.Local7 | pri.Debug,
+			expected: true,
+		},
+		"invalid facility never matches": {
+			input:    SelectAll(),
+			inputPri: pri.Priority(0xF8),
+			expected: false,
+		},
+	}
+
+	for explanation, test := range tests {
+		actual := test.input.Match(test.inputPri)
+
+		assert.Equal(
+			t,
+			test.expected,
+			actual,
+			"Selector Match test failed for: %s",
+			explanation,
+		)
+	}
+}
+
+func TestSelectorString(t *testing.T) {
+	type testCase struct {
+		input    string
+		expected string
+	}
+
+	tests := map[string]testCase{
+		"nothing": {
+			input:    "*.none",
+			expected: "*.none",
+		},
+		"everything": {
+			input:    "*.*",
+			expected: "*.*",
+		},
+		"everything up to a severity": {
+			input:    "*.warn",
+			expected: "*.warning",
+		},
+		"grouped facilities": {
+			input:    "authpriv,auth.*;kern.=err;kern.=info",
+			expected: "kern.=err;kern.=info;auth,authpriv.*",
+		},
+	}
+
+	for explanation, test := range tests {
+		s, e := ParseSelector(test.input)
+		assert.NoError(t, e, "Error during ParseSelector")
+
+		actual := s.String()
+
+		assert.Equal(
+			t,
+			test.expected,
+			actual,
+			"Selector String test failed for: %s",
+			explanation,
+		)
+
+		roundTrip, e := ParseSelector(actual)
+		assert.NoError(
+			t,
+			e,
+			"Selector String test expects a parseable result"+
+				" for: %s",
+			explanation,
+		)
+		assert.Equal(
+			t,
+			s,
+			roundTrip,
+			"Selector String test expects a round trip for: %s",
+			explanation,
+		)
+	}
+}
+
+func TestSelectorGetFromEnv(t *testing.T) {
+	type testCase struct {
+		input    *string
+		expected Selector
+	}
+
+	str := func(s string) *string {
+		return &s
+	}
+
+	mailOnly := Selector{}
+	mailOnly[pri.Mail>>3] = Mask(0xFF)
+
+	tests := map[string]testCase{
+		"not set": {
+			input:    nil,
+			expected: SelectAll(),
+		},
+		"valid selector": {
+			input:    str("mail.*"),
+			expected: mailOnly,
+		},
+		"invalid selector": {
+			input:    str("mail"),
+			expected: SelectAll(),
+		},
+	}
+
+	for explanation, test := range tests {
+		e := os.Unsetenv("LOG_SELECTOR")
+		assert.NoError(t, e, "Error during Unsetenv")
+
+		if test.input != nil {
+			e := os.Setenv("LOG_SELECTOR", *test.input)
+			assert.NoError(t, e, "Error during Setenv")
+		}
+
+		actual := GetSelectorFromEnv()
+
+		assert.Equal(
+			t,
+			test.expected,
+			actual,
+			"mask.GetSelectorFromEnv test failed for: %s",
+			explanation,
+		)
+	}
+}
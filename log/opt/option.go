@@ -57,6 +57,11 @@ const (
 	// behavior, but proidiot likes it. This option is effectively
 	// meaningless if Perror is set.
 	NoFallback Option = 0x40
+
+	// Caller enables logging the source file, line, and function of the
+	// code which logged each message. This option isn't POSIX, and finding
+	// the caller has a cost for every message which isn't masked.
+	Caller Option = 0x80
 )
 
 var lookup = map[Option]string{
@@ -67,6 +72,7 @@ var lookup = map[Option]string{
 	NoWait:     "LOG_NOWAIT",
 	Perror:     "LOG_PERROR",
 	NoFallback: "LOG_NOFALLBACK",
+	Caller:     "LOG_CALLER",
 }
 
 // GetFromEnv gives the bitwise-or of the Options indicated by environment
@@ -108,3 +114,35 @@ func (o Option) String() string {
 
 	return strings.Join(oset, "|")
 }
+
+// Parse gives the bitwise-or of the Options described by a string of the form
+// given by String (such as "LOG_PID|LOG_PERROR"). An empty string describes
+// no Options.
+func Parse(s string) (Option, error) {
+	s = strings.TrimSpace(s)
+	if s == "" || s == fmt.Sprintf("Option(%#x)", 0) {
+		return Option(0), nil
+	}
+
+	var o Option
+
+optLoop:
+	for _, val := range strings.Split(s, "|") {
+		for oflag, ostring := range lookup {
+			if val == ostring {
+				o |= oflag
+				continue optLoop
+			}
+		}
+
+		return Option(0), fmt.Errorf(
+			"Options must be a bitwise or of option names such as"+
+				" LOG_PID, but %q contains the unexpected"+
+				" value %q.",
+			s,
+			val,
+		)
+	}
+
+	return o, nil
+}
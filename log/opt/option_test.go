@@ -16,6 +16,7 @@ func TestOptGetFromEnv(t *testing.T) {
 		"LOG_NOWAIT",
 		"LOG_PERROR",
 		"LOG_NOFALLBACK",
+		"LOG_CALLER",
 	}
 
 	type testCase struct {
@@ -79,6 +80,13 @@ func TestOptGetFromEnv(t *testing.T) {
 			expected:    NoFallback,
 			explanation: "nofallback only",
 		},
+		{
+			env: []string{
+				"LOG_CALLER",
+			},
+			expected:    Caller,
+			explanation: "caller only",
+		},
 		{
 			env: []string{
 				"LOG_PID",
@@ -190,14 +198,21 @@ func TestOptString(t *testing.T) {
 			explanation: "all opts",
 		},
 		{
-			input:       0x80,
-			expected:    "Option(0x80)",
-			explanation: "unknown opt",
+			input:       Caller,
+			expected:    "LOG_CALLER",
+			explanation: "caller only",
 		},
 		{
-			input:       0x84,
-			expected:    "LOG_ODELAY|Option(0x80)",
-			explanation: "odelay and unknown opt",
+			input:       ODelay | Caller,
+			expected:    "LOG_ODELAY|LOG_CALLER",
+			explanation: "odelay and caller",
+		},
+		{
+			input: 0xFF,
+			expected: "LOG_PID|LOG_CONS|LOG_ODELAY|LOG_NDELAY" +
+				"|LOG_NOWAIT|LOG_PERROR|LOG_NOFALLBACK" +
+				"|LOG_CALLER",
+			explanation: "all opts with caller",
 		},
 	}
 
@@ -213,3 +228,61 @@ func TestOptString(t *testing.T) {
 		)
 	}
 }
+
+func TestOptParse(t *testing.T) {
+	type testCase struct {
+		input         string
+		expected      Option
+		expectedError bool
+	}
+
+	tests := map[string]testCase{
+		"empty": {
+			input:    "",
+			expected: Option(0x0),
+		},
+		"string form of zero": {
+			input:    "Option(0x0)",
+			expected: Option(0x0),
+		},
+		"single option": {
+			input:    "LOG_PERROR",
+			expected: Perror,
+		},
+		"multiple options": {
+			input:    "LOG_PID|LOG_NDELAY",
+			expected: Pid | NDelay,
+		},
+		"bad value": {
+			input:         "LOG_PID|LOG_BOGUS",
+			expectedError: true,
+		},
+	}
+
+	for explanation, test := range tests {
+		actual, actualError := Parse(test.input)
+
+		if test.expectedError {
+			assert.Error(
+				t,
+				actualError,
+				"opt.Parse test expects an error for: %s",
+				explanation,
+			)
+		} else {
+			assert.NoError(
+				t,
+				actualError,
+				"opt.Parse test expects no error for: %s",
+				explanation,
+			)
+			assert.Equal(
+				t,
+				test.expected,
+				actual,
+				"opt.Parse test failed for: %s",
+				explanation,
+			)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package pri
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/proidiot/gone/errors"
 )
@@ -219,3 +220,40 @@ func GetFromEnv() Priority {
 
 	return User
 }
+
+// Parse gives the Priority described by a string of the form given by String,
+// which is a facility name, a severity name, or a bitwise or of one of each
+// (such as "LOG_LOCAL0|LOG_ERR").
+func Parse(s string) (Priority, error) {
+	var p Priority
+	facility, severity := false, false
+
+valLoop:
+	for _, val := range strings.Split(strings.TrimSpace(s), "|") {
+		for f, fstring := range lookupFacility {
+			if val == fstring && !facility {
+				facility = true
+				p |= f
+				continue valLoop
+			}
+		}
+
+		for sev, sstring := range lookupSeverity {
+			if val == sstring && !severity {
+				severity = true
+				p |= sev
+				continue valLoop
+			}
+		}
+
+		return Priority(0), fmt.Errorf(
+			"A pri.Priority must be a facility name, a severity"+
+				" name, or a bitwise or of one of each, but"+
+				" %q contains the unexpected value %q.",
+			s,
+			val,
+		)
+	}
+
+	return p, nil
+}
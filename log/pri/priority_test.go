@@ -278,3 +278,69 @@ func TestPriGetFromEnv(t *testing.T) {
 		)
 	}
 }
+
+func TestPriParse(t *testing.T) {
+	type testCase struct {
+		input         string
+		expected      Priority
+		expectedError bool
+	}
+
+	tests := map[string]testCase{
+		"facility": {
+			input:    "LOG_LOCAL0",
+			expected: Local0,
+		},
+		"kern facility": {
+			input:    "LOG_KERN",
+			expected: Kern,
+		},
+		"severity": {
+			input:    "LOG_ERR",
+			expected: Err,
+		},
+		"facility and severity": {
+			input:    "LOG_MAIL|LOG_INFO",
+			expected: Mail | Info,
+		},
+		"two facilities": {
+			input:         "LOG_MAIL|LOG_USER",
+			expectedError: true,
+		},
+		"two severities": {
+			input:         "LOG_ERR|LOG_INFO",
+			expectedError: true,
+		},
+		"bad value": {
+			input:         "LOG_BOGUS",
+			expectedError: true,
+		},
+	}
+
+	for explanation, test := range tests {
+		actual, actualError := Parse(test.input)
+
+		if test.expectedError {
+			assert.Error(
+				t,
+				actualError,
+				"pri.Parse test expects an error for: %s",
+				explanation,
+			)
+		} else {
+			assert.NoError(
+				t,
+				actualError,
+				"pri.Parse test expects no error for: %s",
+				explanation,
+			)
+			assert.Equal(
+				t,
+				test.expected,
+				actual,
+				"pri.Parse test failed for: %s",
+				explanation,
+			)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package log
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"time"
+
+	"github.com/proidiot/gone/log/config"
+	"github.com/proidiot/gone/log/pri"
+)
+
+// DefaultWatchInterval is the length of time Watch waits between checks of a
+// configuration file when it has not been given an interval.
+const DefaultWatchInterval = time.Second
+
+// Reload builds a new global syslogger.Syslogger from the log configuration
+// file at the given path (as described by log/config) and swaps it in as if by
+// SetSyslogger. If the configuration can't be built then the current global
+// syslogger.Syslogger is kept. An error is also given if the previous global
+// syslogger.Syslogger could not be closed, although the new one is used
+// regardless.
+func Reload(path string) error {
+	s, e := config.Load(path)
+	if e != nil {
+		return e
+	}
+
+	return swap(s)
+}
+
+// Watch checks the log configuration file at the given path for changes to its
+// modification time or size every interval (where zero means
+// DefaultWatchInterval), and calls Reload whenever it has changed. Any error
+// given by Reload is logged to the global syslogger.Syslogger. The returned
+// function stops the watch, waiting for any reload in progress to finish.
+func Watch(path string, interval time.Duration) (stop func()) {
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	last := statFile(path)
+
+	go func() {
+		defer close(stopped)
+
+		t := time.NewTicker(interval)
+		defer t.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-t.C:
+			}
+
+			st := statFile(path)
+			if st.same(last) {
+				continue
+			}
+			last = st
+
+			reload(path)
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(done)
+		})
+		<-stopped
+	}
+}
+
+// ReloadOn calls Reload with the log configuration file at the given path
+// whenever the process receives one of the given signals. Any error given by
+// Reload is logged to the global syslogger.Syslogger. The returned function
+// stops the handling of the signals, waiting for any reload in progress to
+// finish.
+func ReloadOn(path string, sigs ...os.Signal) (stop func()) {
+	done := make(chan struct{})
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, sigs...)
+
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-c:
+				reload(path)
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signal.Stop(c)
+			close(done)
+		})
+		<-stopped
+	}
+}
+
+func reload(path string) {
+	if e := Reload(path); e != nil {
+		_ = Syslog(
+			pri.Err,
+			fmt.Sprintf(
+				"Unable to reload the log configuration: %s",
+				e,
+			),
+		)
+	}
+}
+
+type fileState struct {
+	exists  bool
+	modTime time.Time
+	size    int64
+}
+
+func statFile(path string) fileState {
+	fi, e := os.Stat(path)
+	if e != nil {
+		return fileState{}
+	}
+
+	return fileState{
+		exists:  true,
+		modTime: fi.ModTime(),
+		size:    fi.Size(),
+	}
+}
+
+func (f fileState) same(o fileState) bool {
+	return f.exists == o.exists &&
+		f.modTime.Equal(o.modTime) &&
+		f.size == o.size
+}
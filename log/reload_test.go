@@ -0,0 +1,264 @@
+package log
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/proidiot/gone/log/syslogger"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeConfig(t *testing.T, path string, name string) {
+	e := ioutil.WriteFile(
+		path,
+		[]byte(`{"type": "named", "name": "`+name+`"}`),
+		0600,
+	)
+	assert.NoError(t, e, "Error during WriteFile")
+}
+
+func waitFor(cond func() bool) bool {
+	for i := 0; i < 200; i++ {
+		if cond() {
+			return true
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return false
+}
+
+func TestSetSysloggerDrain(t *testing.T) {
+	b := &blockingSyslogger{
+		Started: make(chan struct{}),
+		Release: make(chan struct{}),
+	}
+	SetSyslogger(b)
+
+	go func() {
+		_ = Info("blocked")
+	}()
+	<-b.Started
+
+	swapped := make(chan struct{})
+	n := &namedSyslogger{Name: "next"}
+	go func() {
+		SetSyslogger(n)
+		close(swapped)
+	}()
+
+	assert.True(
+		t,
+		waitFor(func() bool { return currentSyslogger() == n }),
+		"SetSyslogger drain test expects the new syslogger to be"+
+			" used while the old one is still draining.",
+	)
+	assert.False(
+		t,
+		b.IsClosed(),
+		"SetSyslogger drain test expects the old syslogger to stay"+
+			" open while a call is using it.",
+	)
+
+	close(b.Release)
+	<-swapped
+
+	assert.True(
+		t,
+		b.IsClosed(),
+		"SetSyslogger drain test expects the old syslogger to be"+
+			" closed once drained.",
+	)
+
+	SetSyslogger(n)
+	assert.False(
+		t,
+		n.Closed,
+		"SetSyslogger drain test expects setting the same syslogger"+
+			" again not to close it.",
+	)
+}
+
+func TestReload(t *testing.T) {
+	dir, e := ioutil.TempDir("", "log")
+	assert.NoError(t, e, "Error during TempDir")
+	defer os.RemoveAll(dir)
+
+	good := filepath.Join(dir, "good.json")
+	writeConfig(t, good, "reloaded")
+
+	bad := filepath.Join(dir, "bad.json")
+	e = ioutil.WriteFile(bad, []byte(`{"type": "bogus"}`), 0600)
+	assert.NoError(t, e, "Error during WriteFile")
+
+	type testCase struct {
+		inputPath     string
+		expectedError bool
+		expectedName  string
+	}
+
+	tests := map[string]testCase{
+		"good config": {
+			inputPath:    good,
+			expectedName: "reloaded",
+		},
+		"bad config": {
+			inputPath:     bad,
+			expectedError: true,
+			expectedName:  "original",
+		},
+		"missing config": {
+			inputPath:     filepath.Join(dir, "missing.json"),
+			expectedError: true,
+			expectedName:  "original",
+		},
+	}
+
+	for explanation, test := range tests {
+		orig := &namedSyslogger{Name: "original"}
+		SetSyslogger(orig)
+
+		actualError := Reload(test.inputPath)
+
+		if test.expectedError {
+			assert.Error(
+				t,
+				actualError,
+				"Reload test expects an error for: %s",
+				explanation,
+			)
+		} else {
+			assert.NoError(
+				t,
+				actualError,
+				"Reload test expects no error for: %s",
+				explanation,
+			)
+		}
+
+		actual, ok := currentSyslogger().(*namedSyslogger)
+		if assert.True(
+			t,
+			ok,
+			"Reload test expects a namedSyslogger for: %s",
+			explanation,
+		) {
+			assert.Equal(
+				t,
+				test.expectedName,
+				actual.Name,
+				"Reload test expects a different syslogger"+
+					" for: %s",
+				explanation,
+			)
+		}
+
+		assert.Equal(
+			t,
+			!test.expectedError,
+			orig.Closed,
+			"Reload test expects the original syslogger to be"+
+				" closed only when replaced for: %s",
+			explanation,
+		)
+	}
+}
+
+func TestReloadClosesFiles(t *testing.T) {
+	dir, e := ioutil.TempDir("", "log")
+	assert.NoError(t, e, "Error during TempDir")
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "config.json")
+	writeFileConfig := func(file string) {
+		e := ioutil.WriteFile(
+			path,
+			[]byte(`{"type": "writer", "file": "`+file+`"}`),
+			0600,
+		)
+		assert.NoError(t, e, "Error during WriteFile")
+	}
+
+	writeFileConfig(filepath.Join(dir, "log"))
+	assert.NoError(t, Reload(path), "Error during Reload")
+
+	w, ok := currentSyslogger().(*syslogger.Writer)
+	if !assert.True(
+		t,
+		ok,
+		"Reload file test expects a syslogger.Writer.",
+	) {
+		return
+	}
+	f := w.Writer.(*os.File)
+
+	writeFileConfig("stderr")
+	assert.NoError(t, Reload(path), "Error during Reload")
+
+	_, e = f.Write([]byte("x"))
+	assert.Error(
+		t,
+		e,
+		"Reload file test expects the replaced file to be closed.",
+	)
+
+	SetSyslogger(&namedSyslogger{Name: "next"})
+
+	_, e = os.Stderr.Stat()
+	assert.NoError(
+		t,
+		e,
+		"Reload file test expects stderr never to be closed.",
+	)
+}
+
+func TestWatch(t *testing.T) {
+	dir, e := ioutil.TempDir("", "log")
+	assert.NoError(t, e, "Error during TempDir")
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "log.json")
+	writeConfig(t, path, "first")
+
+	SetSyslogger(&namedSyslogger{Name: "original"})
+
+	stop := Watch(path, 10*time.Millisecond)
+	defer stop()
+
+	name := func() string {
+		if n, ok := currentSyslogger().(*namedSyslogger); ok {
+			return n.Name
+		}
+		return ""
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(
+		t,
+		"original",
+		name(),
+		"Watch test expects no reload before the file changes.",
+	)
+
+	writeConfig(t, path, "second-longer")
+
+	assert.True(
+		t,
+		waitFor(func() bool { return name() == "second-longer" }),
+		"Watch test expects a reload after the file changes.",
+	)
+
+	stop()
+	stop()
+	writeConfig(t, path, "third")
+	time.Sleep(50 * time.Millisecond)
+
+	assert.Equal(
+		t,
+		"second-longer",
+		name(),
+		"Watch test expects no reload after the watch is stopped.",
+	)
+}
@@ -0,0 +1,15 @@
+//go:build !windows && !plan9
+// +build !windows,!plan9
+
+package log
+
+import (
+	"syscall"
+)
+
+// ReloadOnSignal calls Reload with the log configuration file at the given path
+// whenever the process receives SIGUSR1. The returned function stops the
+// handling of the signal.
+func ReloadOnSignal(path string) (stop func()) {
+	return ReloadOn(path, syscall.SIGUSR1)
+}
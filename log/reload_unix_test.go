@@ -0,0 +1,40 @@
+//go:build !windows && !plan9
+// +build !windows,!plan9
+
+package log
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReloadOnSignal(t *testing.T) {
+	dir, e := ioutil.TempDir("", "log")
+	assert.NoError(t, e, "Error during TempDir")
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "log.json")
+	writeConfig(t, path, "signaled")
+
+	SetSyslogger(&namedSyslogger{Name: "original"})
+
+	stop := ReloadOnSignal(path)
+	defer stop()
+
+	e = syscall.Kill(os.Getpid(), syscall.SIGUSR1)
+	assert.NoError(t, e, "Error during Kill")
+
+	assert.True(
+		t,
+		waitFor(func() bool {
+			n, ok := currentSyslogger().(*namedSyslogger)
+			return ok && n.Name == "signaled"
+		}),
+		"ReloadOnSignal test expects a reload after SIGUSR1.",
+	)
+}
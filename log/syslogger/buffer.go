@@ -0,0 +1,103 @@
+package syslogger
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/proidiot/gone/log/pri"
+)
+
+// bufferMaxCap is the largest capacity of a byte buffer which is kept for
+// reuse, so that an unusually long message doesn't hold on to its memory.
+const bufferMaxCap = 64 * 1024
+
+// hostnameRefresh is how long a formatting syslogger.Syslogger keeps using a
+// hostname before looking it up again.
+const hostnameRefresh = time.Minute
+
+var bufferPool = sync.Pool{
+	New: func() interface{} {
+		b := make([]byte, 0, Rfc3164MaxLength)
+		return &b
+	},
+}
+
+// getBuffer gives an empty byte buffer. A []byte message is only valid until
+// the Syslog call it was given to returns, so a buffer can be given back with
+// putBuffer as soon as that happens (and a syslogger.Syslogger which keeps a
+// []byte message any longer must copy it).
+func getBuffer() *[]byte {
+	return bufferPool.Get().(*[]byte)
+}
+
+func putBuffer(b *[]byte) {
+	if cap(*b) > bufferMaxCap {
+		return
+	}
+
+	*b = (*b)[:0]
+	bufferPool.Put(b)
+}
+
+// forward passes a message which has been formatted in a byte buffer to
+// another syslogger.Syslogger, keeping the formatted message in the buffer so
+// that any growth is kept once the buffer is given back.
+func forward(s Syslogger, p pri.Priority, buf *[]byte, b []byte) error {
+	*buf = b
+	return s.Syslog(p, b)
+}
+
+// headerCache holds the part of a message header which only depends on the
+// hostname, ident, and pid, so that it doesn't need to be built for each
+// message.
+type headerCache struct {
+	h atomic.Pointer[cachedHeader]
+}
+
+type cachedHeader struct {
+	b     []byte
+	ident string
+	pid   bool
+	at    time.Time
+}
+
+// load gives the cached header for the given ident and pid, or nil if there
+// isn't one or it is due to have its hostname looked up again.
+func (c *headerCache) load(now time.Time, ident string, pid bool) []byte {
+	h := c.h.Load()
+	if h == nil || h.ident != ident || h.pid != pid {
+		return nil
+	}
+
+	if now.Sub(h.at) >= hostnameRefresh || now.Before(h.at) {
+		return nil
+	}
+
+	return h.b
+}
+
+func (c *headerCache) store(now time.Time, ident string, pid bool, b []byte) {
+	c.h.Store(&cachedHeader{
+		b:     b,
+		ident: ident,
+		pid:   pid,
+		at:    now,
+	})
+}
+
+// hostname gives the hostname (or just the first label of it if short is set),
+// or localhost if it can't be found.
+func hostname(short bool) string {
+	h, e := osHostname()
+	if e != nil {
+		return "localhost"
+	}
+
+	if short {
+		h = strings.SplitN(h, ".", 2)[0]
+	}
+
+	return h
+}
@@ -0,0 +1,154 @@
+package syslogger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/proidiot/gone/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeaderCache(t *testing.T) {
+	start := time.Date(2006, time.January, 2, 15, 4, 5, 0, time.UTC)
+
+	type testCase struct {
+		inputNow      time.Time
+		inputIdent    string
+		inputPid      bool
+		expectedCache bool
+	}
+
+	tests := map[string]testCase{
+		"same values": {
+			inputNow:      start.Add(time.Second),
+			inputIdent:    "ident",
+			expectedCache: true,
+		},
+		"different ident": {
+			inputNow:      start.Add(time.Second),
+			inputIdent:    "other",
+			expectedCache: false,
+		},
+		"different pid": {
+			inputNow:      start.Add(time.Second),
+			inputIdent:    "ident",
+			inputPid:      true,
+			expectedCache: false,
+		},
+		"hostname refresh": {
+			inputNow:      start.Add(hostnameRefresh),
+			inputIdent:    "ident",
+			expectedCache: false,
+		},
+		"clock moved back": {
+			inputNow:      start.Add(-time.Second),
+			inputIdent:    "ident",
+			expectedCache: false,
+		},
+	}
+
+	for explanation, test := range tests {
+		var c headerCache
+
+		assert.Nil(
+			t,
+			c.load(start, "ident", false),
+			"Header cache test expects nothing before a store for:"+
+				" %s",
+			explanation,
+		)
+
+		c.store(start, "ident", false, []byte("header"))
+
+		actual := c.load(test.inputNow, test.inputIdent, test.inputPid)
+		if test.expectedCache {
+			assert.Equal(
+				t,
+				[]byte("header"),
+				actual,
+				"Header cache test expects the cached header"+
+					" for: %s",
+				explanation,
+			)
+		} else {
+			assert.Nil(
+				t,
+				actual,
+				"Header cache test expects no cached header"+
+					" for: %s",
+				explanation,
+			)
+		}
+	}
+}
+
+func TestHostname(t *testing.T) {
+	origOsHostname := osHostname
+	defer func() {
+		osHostname = origOsHostname
+	}()
+
+	type testCase struct {
+		inputHostname      string
+		inputShort         bool
+		causeHostnameError bool
+		expectedHostname   string
+	}
+
+	tests := map[string]testCase{
+		"full": {
+			inputHostname:    "host.example.com",
+			expectedHostname: "host.example.com",
+		},
+		"short": {
+			inputHostname:    "host.example.com",
+			inputShort:       true,
+			expectedHostname: "host",
+		},
+		"error": {
+			causeHostnameError: true,
+			expectedHostname:   "localhost",
+		},
+	}
+
+	for explanation, test := range tests {
+		osHostname = func() (string, error) {
+			if test.causeHostnameError {
+				return "", errors.New(
+					"Artificial error for os.Hostname",
+				)
+			}
+
+			return test.inputHostname, nil
+		}
+
+		assert.Equal(
+			t,
+			test.expectedHostname,
+			hostname(test.inputShort),
+			"Hostname test expects a different hostname for: %s",
+			explanation,
+		)
+	}
+}
+
+func TestPutBuffer(t *testing.T) {
+	b := make([]byte, 10, bufferMaxCap+1)
+	putBuffer(&b)
+	assert.Len(
+		t,
+		b,
+		10,
+		"Buffer test expects an oversized buffer to be dropped as it"+
+			" is.",
+	)
+
+	b = make([]byte, 10, bufferMaxCap)
+	putBuffer(&b)
+	assert.Len(
+		t,
+		b,
+		0,
+		"Buffer test expects a buffer to be emptied when it is kept.",
+	)
+}
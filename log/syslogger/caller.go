@@ -0,0 +1,84 @@
+package syslogger
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// callerDepth is the most stack frames which are examined when looking for the
+// code which logged a message.
+const callerDepth = 64
+
+// logPackage is the import path of the log package. Functions in it (or in any
+// of its subpackages, such as this one) are skipped when looking for the code
+// which logged a message, so the number of helpers a message passes through on
+// its way to a syslogger.Syslogger doesn't matter.
+var logPackage = path.Dir(reflect.TypeOf(Writer{}).PkgPath())
+
+// callerFrame gives the stack frame of the code which logged a message, which
+// is the first frame outside of the log package and the runtime package. Frames
+// from test files are never skipped.
+func callerFrame() (runtime.Frame, bool) {
+	var pcs [callerDepth]uintptr
+	n := runtime.Callers(2, pcs[:])
+
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		f, more := frames.Next()
+		if !skipFrame(f) {
+			return f, true
+		} else if !more {
+			return runtime.Frame{}, false
+		}
+	}
+}
+
+func skipFrame(f runtime.Frame) bool {
+	if strings.HasSuffix(f.File, "_test.go") {
+		return false
+	}
+
+	return strings.HasPrefix(f.Function, "runtime.") ||
+		strings.HasPrefix(f.Function, logPackage+".") ||
+		strings.HasPrefix(f.Function, logPackage+"/")
+}
+
+// callerPrefix gives the source location of the code which logged a message in
+// the form "[file.go:42 package.Function] ", or an empty string if it can't be
+// found.
+func callerPrefix() string {
+	f, ok := callerFrame()
+	if !ok {
+		return ""
+	}
+
+	fn := f.Function
+	if i := strings.LastIndexByte(fn, '/'); i >= 0 {
+		fn = fn[i+1:]
+	}
+
+	return "[" + filepath.Base(f.File) + ":" + strconv.Itoa(f.Line) + " " +
+		fn + "] "
+}
+
+// messageText gives the text of a message which is a string, []byte,
+// fmt.Stringer, or error.
+func messageText(msg interface{}) (string, bool) {
+	switch m := msg.(type) {
+	case string:
+		return m, true
+	case []byte:
+		return string(m), true
+	case fmt.Stringer:
+		return m.String(), true
+	case error:
+		return errorText(m), true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,203 @@
+package syslogger
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+	"testing"
+
+	"github.com/proidiot/gone/errors"
+	"github.com/proidiot/gone/log/pri"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCallerFormatters(t *testing.T) {
+	type testCase struct {
+		inputWrap func(Syslogger) Syslogger
+		expected  string
+	}
+
+	tests := map[string]testCase{
+		"HumanReadable": {
+			inputWrap: func(s Syslogger) Syslogger {
+				return &HumanReadable{
+					Syslogger: s,
+					Ident:     "app",
+					Caller:    true,
+				}
+			},
+			expected: ` app \[caller_test\.go:\d+` +
+				` syslogger\.TestCallerFormatters\] message$`,
+		},
+		"Rfc3164": {
+			inputWrap: func(s Syslogger) Syslogger {
+				return &Rfc3164{
+					Syslogger: s,
+					Ident:     "app",
+					Caller:    true,
+				}
+			},
+			expected: ` app: \[caller_test\.go:\d+` +
+				` syslogger\.TestCallerFormatters\] message$`,
+		},
+		"Rfc3164 without Caller": {
+			inputWrap: func(s Syslogger) Syslogger {
+				return &Rfc3164{
+					Syslogger: s,
+					Ident:     "app",
+				}
+			},
+			expected: ` app: message$`,
+		},
+	}
+
+	for explanation, test := range tests {
+		var b bytes.Buffer
+		s := test.inputWrap(&Writer{Writer: &b})
+
+		e := s.Syslog(pri.Info, "message")
+		assert.NoError(
+			t,
+			e,
+			"Caller formatter test expects no error for: %s",
+			explanation,
+		)
+
+		assert.Regexp(
+			t,
+			test.expected,
+			b.String(),
+			"Caller formatter test expects a different message"+
+				" for: %s",
+			explanation,
+		)
+	}
+}
+
+func TestCallerPrefix(t *testing.T) {
+	_, _, line, _ := runtime.Caller(0)
+	actual := callerPrefix()
+
+	assert.Equal(
+		t,
+		"[caller_test.go:"+strconv.Itoa(line+1)+
+			" syslogger.TestCallerPrefix] ",
+		actual,
+		"Caller prefix test expects the location of the test.",
+	)
+}
+
+func TestSkipFrame(t *testing.T) {
+	type testCase struct {
+		input    runtime.Frame
+		expected bool
+	}
+
+	tests := map[string]testCase{
+		"log package": {
+			input: runtime.Frame{
+				Function: logPackage + ".Info",
+				File:     "/src/log/log.go",
+			},
+			expected: true,
+		},
+		"log subpackage": {
+			input: runtime.Frame{
+				Function: logPackage + "/syslogger.NewDelay",
+				File:     "/src/log/syslogger/delay.go",
+			},
+			expected: true,
+		},
+		"runtime": {
+			input: runtime.Frame{
+				Function: "runtime.gopanic",
+				File:     "/go/src/runtime/panic.go",
+			},
+			expected: true,
+		},
+		"log package test": {
+			input: runtime.Frame{
+				Function: logPackage + ".TestInfo",
+				File:     "/src/log/log_test.go",
+			},
+			expected: false,
+		},
+		"similar package name": {
+			input: runtime.Frame{
+				Function: logPackage + "ger.Info",
+				File:     "/src/logger/logger.go",
+			},
+			expected: false,
+		},
+		"main": {
+			input: runtime.Frame{
+				Function: "main.main",
+				File:     "/src/main.go",
+			},
+			expected: false,
+		},
+	}
+
+	for explanation, test := range tests {
+		assert.Equal(
+			t,
+			test.expected,
+			skipFrame(test.input),
+			"Skip frame test expects a different result for: %s",
+			explanation,
+		)
+	}
+}
+
+func TestMessageText(t *testing.T) {
+	type testCase struct {
+		input        interface{}
+		expected     string
+		expectedText bool
+	}
+
+	tests := map[string]testCase{
+		"string": {
+			input:        "message",
+			expected:     "message",
+			expectedText: true,
+		},
+		"byte slice": {
+			input:        []byte("message"),
+			expected:     "message",
+			expectedText: true,
+		},
+		"stringer": {
+			input:        &stringer{"message"},
+			expected:     "message",
+			expectedText: true,
+		},
+		"error": {
+			input:        errors.New("message"),
+			expected:     "message",
+			expectedText: true,
+		},
+		"unsupported type": {
+			input: 42,
+		},
+	}
+
+	for explanation, test := range tests {
+		actual, ok := messageText(test.input)
+
+		assert.Equal(
+			t,
+			test.expectedText,
+			ok,
+			"Message text test expects a different result for: %s",
+			explanation,
+		)
+		assert.Equal(
+			t,
+			test.expected,
+			actual,
+			"Message text test expects a different text for: %s",
+			explanation,
+		)
+	}
+}
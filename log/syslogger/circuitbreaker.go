@@ -0,0 +1,140 @@
+package syslogger
+
+import (
+	"sync"
+	"time"
+
+	"github.com/proidiot/gone/errors"
+	"github.com/proidiot/gone/log/pri"
+)
+
+const (
+	// DefaultCircuitBreakerThreshold is the number of consecutive failures
+	// after which a CircuitBreaker which has not been given a threshold
+	// will open.
+	DefaultCircuitBreakerThreshold = 5
+
+	// DefaultCircuitBreakerCooldown is the length of time a CircuitBreaker
+	// which has not been given a cooldown will remain open.
+	DefaultCircuitBreakerCooldown = 30 * time.Second
+
+	// CircuitOpen is a standard error that indicates that a CircuitBreaker
+	// has refused to pass a message along since it is open.
+	CircuitOpen = errors.New(
+		"The syslogger.CircuitBreaker is open because the syslogger" +
+			" it protects has failed repeatedly, so no attempt" +
+			" has been made to pass the message along.",
+	)
+)
+
+// CircuitBreaker is a syslogger.Syslogger that stops trying to log to another
+// syslogger.Syslogger for a cooldown period after that syslogger.Syslogger has
+// failed a number of times in a row. While the CircuitBreaker is open every
+// message fails immediately with CircuitOpen, which allows a Fallthrough (or
+// similar) to route around a dead destination without waiting on it for every
+// message. Once the cooldown has passed, a single message is allowed through
+// as a probe: if it succeeds the CircuitBreaker closes, and otherwise it opens
+// for another cooldown period.
+type CircuitBreaker struct {
+	Syslogger Syslogger
+
+	// Threshold is the number of consecutive failures which cause the
+	// CircuitBreaker to open, and zero means
+	// DefaultCircuitBreakerThreshold.
+	Threshold int
+
+	// Cooldown is the length of time the CircuitBreaker remains open, and
+	// zero means DefaultCircuitBreakerCooldown.
+	Cooldown time.Duration
+
+	// Failure reports whether an error from the other syslogger.Syslogger
+	// counts as a failure of it (rather than a problem with the message),
+	// and nil means that every error counts. An error which doesn't count
+	// is still given, but leaves the CircuitBreaker as it was.
+	Failure func(error) bool
+
+	failures  int
+	openUntil time.Time
+	probing   bool
+	x         sync.Mutex
+
+	co closeOnce
+}
+
+// Syslog logs a message. In the case of CircuitBreaker, the message is passed
+// to another syslogger.Syslogger unless the CircuitBreaker is open.
+func (c *CircuitBreaker) Syslog(p pri.Priority, msg interface{}) error {
+	if c.Syslogger == nil {
+		return errors.New(
+			"A syslogger.CircuitBreaker must have a non-nil" +
+				" syslogger in order to be meaningful, but an" +
+				" attempt has been made to write a log to a" +
+				" syslogger.CircuitBreaker with a nil" +
+				" syslogger.",
+		)
+	}
+
+	c.x.Lock()
+	probe := !c.openUntil.IsZero()
+	if probe && (c.probing || timeNow().Before(c.openUntil)) {
+		c.x.Unlock()
+		return CircuitOpen
+	}
+	c.probing = probe
+	c.x.Unlock()
+
+	// Not holding the lock here because the actual Syslog call may take
+	// some time.
+	e := c.Syslogger.Syslog(p, msg)
+
+	c.x.Lock()
+	defer c.x.Unlock()
+
+	c.probing = false
+	if e == nil {
+		c.failures = 0
+		c.openUntil = time.Time{}
+		return nil
+	} else if c.Failure != nil && !c.Failure(e) {
+		return e
+	}
+
+	threshold := c.Threshold
+	if threshold <= 0 {
+		threshold = DefaultCircuitBreakerThreshold
+	}
+
+	cooldown := c.Cooldown
+	if cooldown <= 0 {
+		cooldown = DefaultCircuitBreakerCooldown
+	}
+
+	c.failures++
+	if probe || c.failures >= threshold {
+		c.failures = 0
+		c.openUntil = timeNow().Add(cooldown)
+	}
+
+	return e
+}
+
+// Open indicates whether the CircuitBreaker is currently refusing to pass
+// messages along.
+func (c *CircuitBreaker) Open() bool {
+	c.x.Lock()
+	defer c.x.Unlock()
+	return !c.openUntil.IsZero() && timeNow().Before(c.openUntil)
+}
+
+// Flush flushes the other syslogger.Syslogger.
+func (c *CircuitBreaker) Flush() error {
+	return flushAll(c.Syslogger)
+}
+
+// Close closes the other syslogger.Syslogger, only the first time it is
+// called.
+func (c *CircuitBreaker) Close() error {
+	return c.co.close(func() error {
+		return closeAll(c.Syslogger)
+	})
+}
@@ -0,0 +1,249 @@
+package syslogger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/proidiot/gone/errors"
+	"github.com/proidiot/gone/log/pri"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCircuitBreakerSyslog(t *testing.T) {
+	origTimeNow := timeNow
+	defer func() {
+		timeNow = origTimeNow
+	}()
+
+	type step struct {
+		advance       time.Duration
+		expectedError error
+		expectedOpen  bool
+	}
+
+	type testCase struct {
+		inputNilSyslogger bool
+		inputFailures     int
+		inputThreshold    int
+		inputCooldown     time.Duration
+		inputFailure      func(error) bool
+		steps             []step
+		expectedCalls     int
+	}
+
+	artificial := errors.New("Artificial error from a failingSyslogger")
+
+	tests := map[string]testCase{
+		"nil values": {
+			inputNilSyslogger: true,
+			steps: []step{
+				{expectedError: errors.New("nil")},
+			},
+		},
+		"healthy": {
+			steps: []step{
+				{},
+				{},
+			},
+			expectedCalls: 2,
+		},
+		"below threshold": {
+			inputFailures:  2,
+			inputThreshold: 3,
+			steps: []step{
+				{expectedError: artificial},
+				{expectedError: artificial},
+				{},
+			},
+			expectedCalls: 3,
+		},
+		"opens at threshold": {
+			inputFailures:  10,
+			inputThreshold: 2,
+			inputCooldown:  time.Minute,
+			steps: []step{
+				{expectedError: artificial},
+				{
+					expectedError: artificial,
+					expectedOpen:  true,
+				},
+				{
+					expectedError: CircuitOpen,
+					expectedOpen:  true,
+				},
+				{
+					advance:       59 * time.Second,
+					expectedError: CircuitOpen,
+					expectedOpen:  true,
+				},
+			},
+			expectedCalls: 2,
+		},
+		"failed probe reopens": {
+			inputFailures:  10,
+			inputThreshold: 1,
+			inputCooldown:  time.Minute,
+			steps: []step{
+				{
+					expectedError: artificial,
+					expectedOpen:  true,
+				},
+				{
+					advance:       time.Minute,
+					expectedError: artificial,
+					expectedOpen:  true,
+				},
+				{
+					expectedError: CircuitOpen,
+					expectedOpen:  true,
+				},
+			},
+			expectedCalls: 2,
+		},
+		"successful probe closes": {
+			inputFailures:  1,
+			inputThreshold: 1,
+			steps: []step{
+				{
+					expectedError: artificial,
+					expectedOpen:  true,
+				},
+				{advance: DefaultCircuitBreakerCooldown},
+				{},
+			},
+			expectedCalls: 3,
+		},
+		"uncounted failures": {
+			inputFailures:  10,
+			inputThreshold: 1,
+			inputFailure: func(error) bool {
+				return false
+			},
+			steps: []step{
+				{expectedError: artificial},
+				{expectedError: artificial},
+				{expectedError: artificial},
+			},
+			expectedCalls: 3,
+		},
+		"counted failures": {
+			inputFailures:  10,
+			inputThreshold: 1,
+			inputFailure: func(error) bool {
+				return true
+			},
+			steps: []step{
+				{
+					expectedError: artificial,
+					expectedOpen:  true,
+				},
+				{
+					expectedError: CircuitOpen,
+					expectedOpen:  true,
+				},
+			},
+			expectedCalls: 1,
+		},
+		"default threshold": {
+			inputFailures: 10,
+			steps: []step{
+				{expectedError: artificial},
+				{expectedError: artificial},
+				{expectedError: artificial},
+				{expectedError: artificial},
+				{
+					expectedError: artificial,
+					expectedOpen:  true,
+				},
+				{
+					expectedError: CircuitOpen,
+					expectedOpen:  true,
+				},
+			},
+			expectedCalls: DefaultCircuitBreakerThreshold,
+		},
+	}
+
+	for explanation, test := range tests {
+		now := time.Date(2001, 2, 3, 4, 5, 6, 0, time.UTC)
+		timeNow = func() time.Time {
+			return now
+		}
+
+		f := &failingSyslogger{Failures: test.inputFailures}
+
+		c := &CircuitBreaker{
+			Syslogger: f,
+			Threshold: test.inputThreshold,
+			Cooldown:  test.inputCooldown,
+			Failure:   test.inputFailure,
+		}
+		if test.inputNilSyslogger {
+			c.Syslogger = nil
+		}
+
+		for i, s := range test.steps {
+			now = now.Add(s.advance)
+
+			actualError := c.Syslog(pri.Info, "circuit message")
+
+			if s.expectedError == nil {
+				assert.NoError(
+					t,
+					actualError,
+					"CircuitBreaker test expects no error"+
+						" at step %d for: %s",
+					i,
+					explanation,
+				)
+			} else if s.expectedError == CircuitOpen {
+				assert.Equal(
+					t,
+					CircuitOpen,
+					actualError,
+					"CircuitBreaker test expects an open"+
+						" circuit at step %d for: %s",
+					i,
+					explanation,
+				)
+			} else {
+				assert.Errorf(
+					t,
+					actualError,
+					"CircuitBreaker test expects an error"+
+						" at step %d for: %s",
+					i,
+					explanation,
+				)
+				assert.NotEqual(
+					t,
+					CircuitOpen,
+					actualError,
+					"CircuitBreaker test expects a closed"+
+						" circuit at step %d for: %s",
+					i,
+					explanation,
+				)
+			}
+
+			assert.Equal(
+				t,
+				s.expectedOpen,
+				c.Open(),
+				"CircuitBreaker test expects a specific state"+
+					" at step %d for: %s",
+				i,
+				explanation,
+			)
+		}
+
+		assert.Equal(
+			t,
+			test.expectedCalls,
+			f.Calls,
+			"CircuitBreaker test expects a specific number of"+
+				" calls for: %s",
+			explanation,
+		)
+	}
+}
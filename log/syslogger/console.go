@@ -0,0 +1,161 @@
+package syslogger
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/proidiot/gone/errors"
+	"github.com/proidiot/gone/log/pri"
+)
+
+// ColorMode describes when a Console should color its output.
+type ColorMode byte
+
+const (
+	// ColorAuto colors output only if the Console file is a terminal, the
+	// NO_COLOR environment variable is empty or unset, and the TERM
+	// environment variable is not "dumb". This is the default mode.
+	ColorAuto ColorMode = iota
+
+	// ColorAlways colors output regardless of the Console file.
+	ColorAlways
+
+	// ColorNever never colors output.
+	ColorNever
+)
+
+var consoleColors = map[pri.Priority]string{
+	pri.Emerg:   "\x1b[1;31m",
+	pri.Alert:   "\x1b[1;31m",
+	pri.Crit:    "\x1b[1;31m",
+	pri.Err:     "\x1b[31m",
+	pri.Warning: "\x1b[33m",
+	pri.Notice:  "\x1b[1m",
+	pri.Info:    "",
+	pri.Debug:   "\x1b[2m",
+}
+
+const consoleColorReset = "\x1b[0m"
+
+// Console is a syslogger.Syslogger that writes messages to an *os.File (such as
+// stderr) in a human readable way which is meant to be easy to scan in an
+// interactive session. Each message is written on its own line with the
+// timestamp, severity, and identity aligned in columns, and when the file is a
+// terminal the severity and message are colored according to the severity of
+// the message. Unlike HumanReadable, it writes directly to a file rather than
+// to another syslogger.Syslogger since it needs to know whether the output is
+// destined for a terminal. An error which wraps other errors is expanded in the
+// same way as by HumanReadable.
+type Console struct {
+	File  *os.File
+	Ident string
+	Pid   bool
+	Color ColorMode
+
+	// Closer is closed by Close when it is non-nil, and is meant to be set
+	// to the file only when the Console owns it (such as a file which was
+	// opened just for the Console).
+	Closer io.Closer
+
+	color bool
+	once  sync.Once
+	co    closeOnce
+}
+
+// Syslog logs a message. In the case of Console, the message will be given a
+// specific (possibly colored) format and then written to a file.
+func (c *Console) Syslog(p pri.Priority, msg interface{}) error {
+	var s string
+	switch msg := msg.(type) {
+	case string:
+		s = msg
+	case []byte:
+		s = string(msg)
+	case fmt.Stringer:
+		s = msg.String()
+	case error:
+		s = errorText(msg)
+	default:
+		return errors.New(
+			"The *syslogger.Console expects the message argument" +
+				" to have the type string, []byte," +
+				" fmt.Stringer, or error, but the given" +
+				" message argument does not have one of" +
+				" these types.",
+		)
+	}
+
+	if c.File == nil {
+		return errors.New(
+			"A syslogger.Console must have a non-nil file in" +
+				" order to be meaningful, but an attempt has" +
+				" been made to write a log to a" +
+				" syslogger.Console with a nil file.",
+		)
+	}
+
+	c.once.Do(func() {
+		c.color = c.colored()
+	})
+
+	ident := c.Ident
+	if ident == "" {
+		ident = os.Args[0]
+	}
+
+	if c.Pid {
+		ident = fmt.Sprintf(
+			"%s[%d]",
+			ident,
+			os.Getpid(),
+		)
+	}
+
+	color, reset := "", ""
+	if c.color && consoleColors[p.Severity()] != "" {
+		color, reset = consoleColors[p.Severity()], consoleColorReset
+	}
+
+	_, e := io.WriteString(
+		c.File,
+		fmt.Sprintf(
+			"%s %s%-11s %s: %s%s\n",
+			time.Now().Format(time.Stamp),
+			color,
+			p.Severity(),
+			ident,
+			s,
+			reset,
+		),
+	)
+	return e
+}
+
+func (c *Console) colored() bool {
+	switch c.Color {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	default:
+		return osIsTerminal(c.File) &&
+			os.Getenv("NO_COLOR") == "" &&
+			os.Getenv("TERM") != "dumb"
+	}
+}
+
+// Close closes the Closer of the Console if it has one, only the first time it
+// is called. The file itself is otherwise never closed, since it may be shared
+// (such as os.Stderr).
+func (c *Console) Close() error {
+	return c.co.close(func() error {
+		if c.Closer == nil {
+			return nil
+		}
+
+		return c.Closer.Close()
+	})
+}
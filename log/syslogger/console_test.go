@@ -0,0 +1,222 @@
+package syslogger
+
+import (
+	"io/ioutil"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/proidiot/gone/errors"
+	"github.com/proidiot/gone/log/pri"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsoleSyslog(t *testing.T) {
+	dateregex := `[A-Z][a-z]{2} [ 123]\d \d{2}:\d{2}:\d{2}`
+
+	origOsIsTerminal := osIsTerminal
+	defer func() {
+		osIsTerminal = origOsIsTerminal
+	}()
+
+	type testCase struct {
+		inputIdent       string
+		inputPid         bool
+		inputColor       ColorMode
+		inputPriority    pri.Priority
+		inputMsg         interface{}
+		inputNilFile     bool
+		inputNoColor     string
+		inputTerm        string
+		causeTerminal    bool
+		expectedError    bool
+		expectedContents *regexp.Regexp
+	}
+
+	tests := map[string]testCase{
+		"nil values": {
+			inputMsg:         nil,
+			expectedError:    true,
+			expectedContents: regexp.MustCompile(`^$`),
+		},
+		"nil file": {
+			inputMsg:      "nil file message",
+			inputNilFile:  true,
+			expectedError: true,
+		},
+		"plain": {
+			inputIdent:    "plain",
+			inputPriority: pri.Warning,
+			inputMsg:      "plain message",
+			expectedContents: regexp.MustCompile(
+				`^` + dateregex + ` LOG_WARNING plain:` +
+					` plain message\n$`,
+			),
+		},
+		"bytes": {
+			inputIdent:    "bytes",
+			inputPriority: pri.Warning,
+			inputMsg:      []byte("bytes message"),
+			expectedContents: regexp.MustCompile(
+				`^` + dateregex + ` LOG_WARNING bytes:` +
+					` bytes message\n$`,
+			),
+		},
+		"aligned": {
+			inputIdent:    "aligned",
+			inputPid:      true,
+			inputPriority: pri.Local3 | pri.Err,
+			inputMsg:      errors.New("aligned message"),
+			expectedContents: regexp.MustCompile(
+				`^` + dateregex + ` LOG_ERR     aligned` +
+					`\[\d+\]: aligned message\n$`,
+			),
+		},
+		"stringer": {
+			inputIdent:    "stringer",
+			inputPriority: pri.Info,
+			inputMsg:      &stringer{"stringer message"},
+			expectedContents: regexp.MustCompile(
+				`^` + dateregex + ` LOG_INFO    stringer:` +
+					` stringer message\n$`,
+			),
+		},
+		"always": {
+			inputIdent:    "always",
+			inputColor:    ColorAlways,
+			inputPriority: pri.Err,
+			inputMsg:      "always message",
+			expectedContents: regexp.MustCompile(
+				`^` + dateregex + ` \x1b\[31mLOG_ERR     ` +
+					`always: always message\x1b\[0m\n$`,
+			),
+		},
+		"always uncolored severity": {
+			inputIdent:    "always",
+			inputColor:    ColorAlways,
+			inputPriority: pri.Info,
+			inputMsg:      "always message",
+			expectedContents: regexp.MustCompile(
+				`^` + dateregex + ` LOG_INFO    always:` +
+					` always message\n$`,
+			),
+		},
+		"never": {
+			inputIdent:    "never",
+			inputColor:    ColorNever,
+			inputPriority: pri.Crit,
+			inputMsg:      "never message",
+			causeTerminal: true,
+			expectedContents: regexp.MustCompile(
+				`^` + dateregex + ` LOG_CRIT    never:` +
+					` never message\n$`,
+			),
+		},
+		"terminal": {
+			inputIdent:    "terminal",
+			inputPriority: pri.Debug,
+			inputMsg:      "terminal message",
+			causeTerminal: true,
+			expectedContents: regexp.MustCompile(
+				`^` + dateregex + ` \x1b\[2mLOG_DEBUG   ` +
+					`terminal: terminal message\x1b\[0m\n$`,
+			),
+		},
+		"terminal with NO_COLOR": {
+			inputIdent:    "nocolor",
+			inputPriority: pri.Debug,
+			inputMsg:      "nocolor message",
+			inputNoColor:  "1",
+			causeTerminal: true,
+			expectedContents: regexp.MustCompile(
+				`^` + dateregex + ` LOG_DEBUG   nocolor:` +
+					` nocolor message\n$`,
+			),
+		},
+		"dumb terminal": {
+			inputIdent:    "dumb",
+			inputPriority: pri.Emerg,
+			inputMsg:      "dumb message",
+			inputTerm:     "dumb",
+			causeTerminal: true,
+			expectedContents: regexp.MustCompile(
+				`^` + dateregex + ` LOG_EMERG   dumb:` +
+					` dumb message\n$`,
+			),
+		},
+	}
+
+	for explanation, test := range tests {
+		osIsTerminal = func(*os.File) bool {
+			return test.causeTerminal
+		}
+
+		origNoColor, noColorSet := os.LookupEnv("NO_COLOR")
+		origTerm, termSet := os.LookupEnv("TERM")
+		_ = os.Setenv("NO_COLOR", test.inputNoColor)
+		_ = os.Setenv("TERM", test.inputTerm)
+
+		f, e := ioutil.TempFile("", "console")
+		require.NoError(
+			t,
+			e,
+			"Console test requires a temporary file.",
+		)
+
+		c := &Console{
+			File:  f,
+			Ident: test.inputIdent,
+			Pid:   test.inputPid,
+			Color: test.inputColor,
+		}
+		if test.inputNilFile {
+			c.File = nil
+		}
+
+		actualError := c.Syslog(test.inputPriority, test.inputMsg)
+
+		if test.expectedError {
+			assert.Errorf(
+				t,
+				actualError,
+				"Console test expects an error for: %s",
+				explanation,
+			)
+		} else {
+			assert.NoError(
+				t,
+				actualError,
+				"Console test expects no error for: %s",
+				explanation,
+			)
+		}
+
+		if test.expectedContents != nil {
+			actualContents, e := ioutil.ReadFile(f.Name())
+			require.NoError(t, e)
+			assert.Regexp(
+				t,
+				test.expectedContents,
+				string(actualContents),
+				"Console test wrote non-matching contents"+
+					" for: %s",
+				explanation,
+			)
+		}
+
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+
+		if noColorSet {
+			_ = os.Setenv("NO_COLOR", origNoColor)
+		} else {
+			_ = os.Unsetenv("NO_COLOR")
+		}
+		if termSet {
+			_ = os.Setenv("TERM", origTerm)
+		} else {
+			_ = os.Unsetenv("TERM")
+		}
+	}
+}
@@ -2,6 +2,7 @@ package syslogger
 
 import (
 	"sync"
+	"sync/atomic"
 
 	"github.com/proidiot/gone/errors"
 	"github.com/proidiot/gone/log/pri"
@@ -14,32 +15,60 @@ type sysloggerHandle struct {
 // Delay is a syslogger.Syslogger that delays the initialization of another
 // syslogger.Syslogger until the first time that Syslog is called.
 type Delay struct {
-	h  *sysloggerHandle
+	h  atomic.Pointer[sysloggerHandle]
 	cb func() (Syslogger, error)
 	x  sync.Mutex
+	co closeOnce
 }
 
 // Syslog logs messages. In the case of Delay, these messages are passed
 // unaltered to another syslogger.Syslogger, and that syslogger.Syslogger would
 // be created at this point if it had not already existed.
 func (d *Delay) Syslog(p pri.Priority, msg interface{}) error {
+	// The lock is only needed until the syslogger.Syslogger is created.
+	if h := d.h.Load(); h != nil {
+		return h.s.Syslog(p, msg)
+	}
+
 	d.x.Lock()
 	// Not deferring the unlock here because the actual Syslog call at the
 	// end may take some time.
-	if d.h == nil {
+	h := d.h.Load()
+	if h == nil {
 		s, e := d.cb()
 		if e != nil {
 			d.x.Unlock()
 			return e
 		}
 
-		d.h = &sysloggerHandle{s}
+		h = &sysloggerHandle{s}
+		d.h.Store(h)
 	}
-	h := d.h
 	d.x.Unlock()
 	return h.s.Syslog(p, msg)
 }
 
+// Flush flushes the other syslogger.Syslogger, if it has been created.
+func (d *Delay) Flush() error {
+	if h := d.h.Load(); h != nil {
+		return flushAll(h.s)
+	}
+
+	return nil
+}
+
+// Close closes the other syslogger.Syslogger (if it has been created), only
+// the first time it is called.
+func (d *Delay) Close() error {
+	return d.co.close(func() error {
+		if h := d.h.Load(); h != nil {
+			return closeAll(h.s)
+		}
+
+		return nil
+	})
+}
+
 // NewDelay gives a Delay syslogger.Syslogger given the callback function which
 // will ultimately be used to create the real syslogger.Syslogger to be used.
 func NewDelay(cb func() (Syslogger, error)) (*Delay, error) {
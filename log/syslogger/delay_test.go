@@ -93,7 +93,7 @@ func TestDelayNewDelay(t *testing.T) {
 				)
 			}
 
-			actualSyslogHandler := n.h
+			actualSyslogHandler := n.h.Load()
 
 			if test.expectedSysloggerCreationError {
 				assert.Nil(
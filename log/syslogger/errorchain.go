@@ -0,0 +1,144 @@
+package syslogger
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// errorChainDepth is the most layers of an error chain which are expanded, in
+// case an error somehow wraps itself.
+const errorChainDepth = 32
+
+// errorFielder is an error with fields which describe it in more detail, such
+// as the name of a file which couldn't be opened.
+type errorFielder interface {
+	Fields() map[string]interface{}
+}
+
+// errorCallerser is an error which has captured the program counters of the
+// stack of the goroutine which created it, as from runtime.Callers.
+type errorCallerser interface {
+	Callers() []uintptr
+}
+
+// errorText gives the text of an error. An error which wraps other errors (with
+// an Unwrap function giving either an error or a []error), has a Fields
+// function, or has a Callers function is expanded: Error is given on the first
+// line, and is followed by a line for each layer of the chain with the type of
+// the error and the part of the message which it added, along with any fields
+// and frames of its stack. Other errors are just given by Error.
+func errorText(e error) string {
+	if !expandable(e) {
+		return e.Error()
+	}
+
+	return string(appendErrorLayer([]byte(e.Error()), e, "\t", 0))
+}
+
+func expandable(e error) bool {
+	if _, ok := e.(errorFielder); ok {
+		return true
+	} else if _, ok := e.(errorCallerser); ok {
+		return true
+	}
+
+	return len(unwrapAll(e)) != 0
+}
+
+// unwrapAll gives the errors which are directly wrapped by an error.
+func unwrapAll(e error) []error {
+	var errs []error
+
+	switch u := e.(type) {
+	case interface{ Unwrap() error }:
+		errs = []error{u.Unwrap()}
+	case interface{ Unwrap() []error }:
+		errs = u.Unwrap()
+	}
+
+	// The []error given by Unwrap must not be changed.
+	var res []error
+	for _, err := range errs {
+		if err != nil {
+			res = append(res, err)
+		}
+	}
+
+	return res
+}
+
+// appendErrorLayer appends the lines describing an error, followed by those of
+// each error which it wraps. The errors wrapped by an error which only wraps a
+// single one are given the same indent, so a simple chain is a flat list.
+func appendErrorLayer(b []byte, e error, indent string, depth int) []byte {
+	if depth >= errorChainDepth {
+		return append(b, "\n"+indent+"..."...)
+	}
+
+	wrapped := unwrapAll(e)
+
+	b = append(b, '\n')
+	b = append(b, indent...)
+	b = append(b, fmt.Sprintf("%T", e)...)
+	if s := ownMessage(e, wrapped); s != "" {
+		b = append(b, ": "...)
+		b = append(b, s...)
+	}
+
+	if f, ok := e.(errorFielder); ok {
+		fields := f.Fields()
+
+		keys := make([]string, 0, len(fields))
+		for k := range fields {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			b = append(b, '\n')
+			b = append(b, indent...)
+			b = fmt.Appendf(b, "\t%s=%v", k, fields[k])
+		}
+	}
+
+	if c, ok := e.(errorCallerser); ok {
+		b = appendFrames(b, c.Callers(), indent+"\t")
+	}
+
+	if len(wrapped) == 1 {
+		return appendErrorLayer(b, wrapped[0], indent, depth+1)
+	}
+
+	for _, w := range wrapped {
+		b = appendErrorLayer(b, w, indent+"\t", depth+1)
+	}
+
+	return b
+}
+
+// ownMessage gives the part of the message of an error which wasn't taken from
+// the errors it wraps. The usual "prefix: wrapped message" form (as given by
+// errors.Wrapper or fmt.Errorf with %w) gives just the prefix, and an error
+// which only joins the messages of the errors it wraps gives nothing.
+func ownMessage(e error, wrapped []error) string {
+	s := e.Error()
+
+	switch len(wrapped) {
+	case 0:
+		return s
+	case 1:
+		return strings.TrimSuffix(s, ": "+wrapped[0].Error())
+	}
+
+	msgs := make([]string, len(wrapped))
+	for i, w := range wrapped {
+		msgs[i] = w.Error()
+	}
+
+	if s == strings.Join(msgs, "\n") {
+		return ""
+	}
+
+	return s
+}
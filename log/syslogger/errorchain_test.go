@@ -0,0 +1,160 @@
+package syslogger
+
+import (
+	stderrors "errors"
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/proidiot/gone/errors"
+	"github.com/proidiot/gone/log/pri"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorText(t *testing.T) {
+	type testCase struct {
+		input    error
+		expected string
+	}
+
+	inner := errors.New("inner")
+	tests := map[string]testCase{
+		"plain error": {
+			input:    inner,
+			expected: "inner",
+		},
+		"wrapped error": {
+			input: fmt.Errorf("outer: %w", inner),
+			expected: "outer: inner\n" +
+				"\t*fmt.wrapError: outer\n" +
+				"\terrors.New: inner",
+		},
+		"several layers": {
+			input: fmt.Errorf(
+				"top: %w",
+				fmt.Errorf("middle: %w", inner),
+			),
+			expected: "top: middle: inner\n" +
+				"\t*fmt.wrapError: top\n" +
+				"\t*fmt.wrapError: middle\n" +
+				"\terrors.New: inner",
+		},
+		"message not built from the cause": {
+			input: &causeError{S: "different", Cause: inner},
+			expected: "different\n" +
+				"\t*syslogger.causeError: different\n" +
+				"\terrors.New: inner",
+		},
+		"nil cause": {
+			input:    &causeError{S: "alone"},
+			expected: "alone",
+		},
+		"joined errors": {
+			input: fmt.Errorf(
+				"outer: %w",
+				stderrors.Join(inner, errors.New("other")),
+			),
+			expected: "outer: inner\nother\n" +
+				"\t*fmt.wrapError: outer\n" +
+				"\t*errors.joinError\n" +
+				"\t\terrors.New: inner\n" +
+				"\t\terrors.New: other",
+		},
+		"fields": {
+			input: &fieldError{
+				S: "bad file",
+				F: map[string]interface{}{
+					"path": "/x",
+					"op":   "open",
+					"size": 42,
+				},
+			},
+			expected: "bad file\n" +
+				"\t*syslogger.fieldError: bad file\n" +
+				"\t\top=open\n" +
+				"\t\tpath=/x\n" +
+				"\t\tsize=42",
+		},
+		"empty stack": {
+			input: &stackError{S: "no stack"},
+			expected: "no stack\n" +
+				"\t*syslogger.stackError: no stack",
+		},
+	}
+
+	for explanation, test := range tests {
+		assert.Equal(
+			t,
+			test.expected,
+			errorText(test.input),
+			"Error text test expects a different text for: %s",
+			explanation,
+		)
+	}
+}
+
+func TestErrorTextStack(t *testing.T) {
+	pcs := make([]uintptr, callerDepth)
+	pcs = pcs[:runtime.Callers(1, pcs)]
+
+	e := fmt.Errorf("outer: %w", &stackError{S: "inner", PCs: pcs})
+
+	assert.Regexp(
+		t,
+		`^outer: inner\n`+
+			`\t\*fmt\.wrapError: outer\n`+
+			`\t\*syslogger\.stackError: inner\n`+
+			`\t\t[^\n]+/syslogger\.TestErrorTextStack\(\)\n`+
+			`\t\t\t[^\n]+/errorchain_test\.go:\d+\n`+
+			`\t\ttesting\.tRunner\(\)\n`+
+			`\t\t\t[^\n]+/testing\.go:\d+$`,
+		errorText(e),
+		"Error text stack test expects the captured frames.",
+	)
+}
+
+func TestErrorTextFormatters(t *testing.T) {
+	e := fmt.Errorf("outer: %w", errors.New("inner"))
+
+	ra := &recordAllSyslogger{}
+	m := &Multiline{
+		Syslogger: ra,
+		Policy:    MultilineSplit,
+	}
+	assert.NoError(
+		t,
+		m.Syslog(pri.Err, e),
+		"Error text formatter test expects no error from Multiline.",
+	)
+	assert.Equal(
+		t,
+		[]string{
+			"outer: inner",
+			MultilineMarker + "\t*fmt.wrapError: outer",
+			MultilineMarker + "\terrors.New: inner",
+		},
+		ra.M,
+		"Error text formatter test expects a line for each layer from"+
+			" Multiline.",
+	)
+
+	rs := &recordStringSyslogger{}
+	h := &HumanReadable{
+		Syslogger: rs,
+		Ident:     "app",
+	}
+	assert.NoError(
+		t,
+		h.Syslog(pri.Err, e),
+		"Error text formatter test expects no error from"+
+			" HumanReadable.",
+	)
+	assert.Regexp(
+		t,
+		` app outer: inner\n\t\*fmt\.wrapError: outer\n`+
+			`\terrors\.New: inner$`,
+		rs.M,
+		"Error text formatter test expects the layers from"+
+			" HumanReadable.",
+	)
+}
@@ -0,0 +1,57 @@
+package syslogger
+
+import (
+	"github.com/proidiot/gone/log/mask"
+	"github.com/proidiot/gone/log/pri"
+)
+
+// FacilityMask is a syslogger.Syslogger that only forwards messages whose
+// facility isn't masked to another syslogger.Syslogger. Messages without a
+// legitimate facility are filtered as if they had the FacilityMask Facility
+// (or pri.User if it is zero), which should match the facility that the other
+// syslogger.Syslogger would give them.
+type FacilityMask struct {
+	Syslogger Syslogger
+	Mask      mask.Facility
+	Facility  pri.Priority
+
+	co closeOnce
+}
+
+// Syslog logs a message. In the case of FacilityMask, the message is sent to
+// another syslogger.Syslogger if and only if the message facility isn't masked.
+func (fm *FacilityMask) Syslog(p pri.Priority, msg interface{}) error {
+	if fm.Mask.Masked(effectiveFacility(p, fm.Facility)) {
+		return nil
+	}
+
+	return fm.Syslogger.Syslog(p, msg)
+}
+
+// effectiveFacility gives the facility of a pri.Priority, or else the given
+// default facility (or pri.User if it is zero) if the pri.Priority doesn't have
+// a legitimate facility.
+func effectiveFacility(p pri.Priority, def pri.Priority) pri.Priority {
+	f := p.Facility()
+	if f.ValidFacility() != nil || f == 0x00 {
+		f = def
+		if f == 0x00 {
+			f = pri.User
+		}
+	}
+
+	return f
+}
+
+// Flush flushes the other syslogger.Syslogger.
+func (fm *FacilityMask) Flush() error {
+	return flushAll(fm.Syslogger)
+}
+
+// Close closes the other syslogger.Syslogger, only the first time it is
+// called.
+func (fm *FacilityMask) Close() error {
+	return fm.co.close(func() error {
+		return closeAll(fm.Syslogger)
+	})
+}
@@ -0,0 +1,115 @@
+package syslogger
+
+import (
+	"testing"
+
+	"github.com/proidiot/gone/log/mask"
+	"github.com/proidiot/gone/log/pri"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFacilityMaskSyslog(t *testing.T) {
+	type testCase struct {
+		inputMask      mask.Facility
+		inputFacility  pri.Priority
+		inputPri       pri.Priority
+		inputBadSyslog bool
+		expectedError  bool
+		expectedCall   bool
+	}
+
+	tests := map[string]testCase{
+		"zero values": {
+			inputMask:    mask.Facility(0x0),
+			inputPri:     pri.Priority(0x0),
+			expectedCall: false,
+		},
+		"full mask": {
+			inputMask:    mask.AllFacilities,
+			inputPri:     pri.Local7 | pri.Debug,
+			expectedCall: true,
+		},
+		"auth explicitly unmasked": {
+			inputMask:    mask.FacilityOf(pri.Auth),
+			inputPri:     pri.Auth | pri.Notice,
+			expectedCall: true,
+		},
+		"auth not unmasked": {
+			inputMask:    mask.FacilityOf(pri.Local0),
+			inputPri:     pri.Auth | pri.Notice,
+			expectedCall: false,
+		},
+		"missing facility uses user by default": {
+			inputMask:    mask.FacilityOf(pri.User),
+			inputPri:     pri.Notice,
+			expectedCall: true,
+		},
+		"missing facility uses given facility": {
+			inputMask:     mask.FacilityOf(pri.User),
+			inputFacility: pri.Daemon,
+			inputPri:      pri.Notice,
+			expectedCall:  false,
+		},
+		"invalid facility uses given facility": {
+			inputMask:     mask.FacilityOf(pri.Daemon),
+			inputFacility: pri.Daemon,
+			inputPri:      pri.Priority(0xF8),
+			expectedCall:  true,
+		},
+		"error passed through": {
+			inputMask:      mask.AllFacilities,
+			inputPri:       pri.Err,
+			inputBadSyslog: true,
+			expectedError:  true,
+		},
+	}
+
+	for explanation, test := range tests {
+		es := errorSyslogger{}
+		fs := flagSyslogger{}
+
+		var s2 Syslogger
+
+		if test.inputBadSyslog {
+			s2 = &es
+		} else {
+			s2 = &fs
+		}
+
+		s := &FacilityMask{
+			Syslogger: s2,
+			Mask:      test.inputMask,
+			Facility:  test.inputFacility,
+		}
+
+		actualError := s.Syslog(test.inputPri, nil)
+
+		if test.expectedError {
+			assert.Errorf(
+				t,
+				actualError,
+				"FacilityMask test expected error for: %s",
+				explanation,
+			)
+		} else {
+			assert.NoError(
+				t,
+				actualError,
+				"FacilityMask test unexpected error for: %s",
+				explanation,
+			)
+		}
+
+		if !test.inputBadSyslog {
+			actualCall := fs.Flag
+
+			assert.Equal(
+				t,
+				test.expectedCall,
+				actualCall,
+				"FacilityMask test call check failure for: %s",
+				explanation,
+			)
+		}
+	}
+}
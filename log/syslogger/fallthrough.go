@@ -11,6 +11,8 @@ import (
 type Fallthrough struct {
 	Default     Syslogger
 	Fallthrough Syslogger
+
+	co closeOnce
 }
 
 // Syslog logs a message. In the case of a Fallthrough, an attempt will be made
@@ -32,3 +34,16 @@ func (f *Fallthrough) Syslog(p pri.Priority, msg interface{}) error {
 		)
 	}
 }
+
+// Flush flushes the other syslogger.Sysloggers.
+func (f *Fallthrough) Flush() error {
+	return flushAll(f.Default, f.Fallthrough)
+}
+
+// Close closes the other syslogger.Sysloggers, only the first time it is
+// called.
+func (f *Fallthrough) Close() error {
+	return f.co.close(func() error {
+		return closeAll(f.Default, f.Fallthrough)
+	})
+}
@@ -0,0 +1,43 @@
+package syslogger
+
+import (
+	"github.com/proidiot/gone/log/mask"
+	"github.com/proidiot/gone/log/pri"
+)
+
+// Filter is a syslogger.Syslogger that only forwards messages selected by a
+// mask.Selector to another syslogger.Syslogger. Messages without a legitimate
+// facility are filtered as if they had the Filter Facility (or pri.User if it
+// is zero), which should match the facility that the other
+// syslogger.Syslogger would give them.
+type Filter struct {
+	Syslogger Syslogger
+	Selector  mask.Selector
+	Facility  pri.Priority
+
+	co closeOnce
+}
+
+// Syslog logs a message. In the case of Filter, the message is sent to another
+// syslogger.Syslogger if and only if the message is selected.
+func (fl *Filter) Syslog(p pri.Priority, msg interface{}) error {
+	f := effectiveFacility(p, fl.Facility)
+	if !fl.Selector.Match(f | p.Severity()) {
+		return nil
+	}
+
+	return fl.Syslogger.Syslog(p, msg)
+}
+
+// Flush flushes the other syslogger.Syslogger.
+func (fl *Filter) Flush() error {
+	return flushAll(fl.Syslogger)
+}
+
+// Close closes the other syslogger.Syslogger, only the first time it is
+// called.
+func (fl *Filter) Close() error {
+	return fl.co.close(func() error {
+		return closeAll(fl.Syslogger)
+	})
+}
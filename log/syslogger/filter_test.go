@@ -0,0 +1,118 @@
+package syslogger
+
+import (
+	"testing"
+
+	"github.com/proidiot/gone/log/mask"
+	"github.com/proidiot/gone/log/pri"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterSyslog(t *testing.T) {
+	type testCase struct {
+		inputSelector  string
+		inputFacility  pri.Priority
+		inputPri       pri.Priority
+		inputBadSyslog bool
+		expectedError  bool
+		expectedCall   bool
+	}
+
+	tests := map[string]testCase{
+		"nothing selected": {
+			inputSelector: "*.none",
+			inputPri:      pri.User | pri.Emerg,
+			expectedCall:  false,
+		},
+		"everything selected": {
+			inputSelector: "*.*",
+			inputPri:      pri.Local7 | pri.Debug,
+			expectedCall:  true,
+		},
+		"auth selected": {
+			inputSelector: "auth.notice",
+			inputPri:      pri.Auth | pri.Err,
+			expectedCall:  true,
+		},
+		"auth severity not selected": {
+			inputSelector: "auth.notice",
+			inputPri:      pri.Auth | pri.Info,
+			expectedCall:  false,
+		},
+		"missing facility uses user by default": {
+			inputSelector: "user.*",
+			inputPri:      pri.Notice,
+			expectedCall:  true,
+		},
+		"missing facility uses given facility": {
+			inputSelector: "user.*",
+			inputFacility: pri.Daemon,
+			inputPri:      pri.Notice,
+			expectedCall:  false,
+		},
+		"invalid facility uses given facility": {
+			inputSelector: "daemon.*",
+			inputFacility: pri.Daemon,
+			inputPri:      pri.Priority(0xF8),
+			expectedCall:  true,
+		},
+		"error passed through": {
+			inputSelector:  "*.*",
+			inputPri:       pri.Err,
+			inputBadSyslog: true,
+			expectedError:  true,
+		},
+	}
+
+	for explanation, test := range tests {
+		es := errorSyslogger{}
+		fs := flagSyslogger{}
+
+		var s2 Syslogger
+
+		if test.inputBadSyslog {
+			s2 = &es
+		} else {
+			s2 = &fs
+		}
+
+		sel, e := mask.ParseSelector(test.inputSelector)
+		assert.NoError(t, e, "Error during ParseSelector")
+
+		s := &Filter{
+			Syslogger: s2,
+			Selector:  sel,
+			Facility:  test.inputFacility,
+		}
+
+		actualError := s.Syslog(test.inputPri, nil)
+
+		if test.expectedError {
+			assert.Errorf(
+				t,
+				actualError,
+				"Filter test expected error for: %s",
+				explanation,
+			)
+		} else {
+			assert.NoError(
+				t,
+				actualError,
+				"Filter test unexpected error for: %s",
+				explanation,
+			)
+		}
+
+		if !test.inputBadSyslog {
+			actualCall := fs.Flag
+
+			assert.Equal(
+				t,
+				test.expectedCall,
+				actualCall,
+				"Filter test call check failure for: %s",
+				explanation,
+			)
+		}
+	}
+}
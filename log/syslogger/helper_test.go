@@ -83,6 +83,9 @@ type recordStringSyslogger struct {
 
 func (rs *recordStringSyslogger) Syslog(p pri.Priority, msg interface{}) error {
 	s, ok := msg.(string)
+	if b, isBytes := msg.([]byte); isBytes {
+		s, ok = string(b), true
+	}
 	if !ok {
 		return errors.New("Non-string passed to a recordStringSyslog")
 	}
@@ -99,6 +102,15 @@ func (e *errorCloser) Close() error {
 	return errors.New("Closing an errorCloser")
 }
 
+type countCloser struct {
+	N int
+}
+
+func (c *countCloser) Close() error {
+	c.N++
+	return nil
+}
+
 type stringer struct {
 	S string
 }
@@ -106,3 +118,154 @@ type stringer struct {
 func (s *stringer) String() string {
 	return string(s.S)
 }
+
+type recordAllSyslogger struct {
+	P []pri.Priority
+	M []string
+}
+
+func (ra *recordAllSyslogger) Syslog(p pri.Priority, msg interface{}) error {
+	s, ok := msg.(string)
+	if b, isBytes := msg.([]byte); isBytes {
+		s, ok = string(b), true
+	}
+	if !ok {
+		return errors.New("Non-string passed to a recordAllSyslogger")
+	}
+
+	ra.M = append(ra.M, s)
+	ra.P = append(ra.P, p)
+	return nil
+}
+
+type failingSyslogger struct {
+	Failures int
+	Calls    int
+}
+
+func (f *failingSyslogger) Syslog(p pri.Priority, msg interface{}) error {
+	f.Calls++
+	if f.Calls <= f.Failures {
+		return errors.New("Artificial error from a failingSyslogger")
+	}
+
+	return nil
+}
+
+type switchSyslogger struct {
+	Fail bool
+	P    []pri.Priority
+	M    []string
+	Raw  []interface{}
+}
+
+func (s *switchSyslogger) Syslog(p pri.Priority, msg interface{}) error {
+	if s.Fail {
+		return errors.New("Artificial error from a switchSyslogger")
+	}
+
+	m, ok := messageText(msg)
+	if !ok {
+		return errors.New("Non-text passed to a switchSyslogger")
+	}
+
+	s.P = append(s.P, p)
+	s.M = append(s.M, m)
+	s.Raw = append(s.Raw, msg)
+	return nil
+}
+
+type chanSyslogger struct {
+	C chan<- string
+}
+
+func (c *chanSyslogger) Syslog(p pri.Priority, msg interface{}) error {
+	if b, ok := msg.([]byte); ok {
+		msg = string(b)
+	}
+
+	s, ok := msg.(string)
+	if !ok {
+		return errors.New("Non-string passed to a chanSyslogger")
+	}
+
+	c.C <- s
+	return nil
+}
+
+type lifecycleSyslogger struct {
+	Flushes int
+	Closes  int
+}
+
+func (l *lifecycleSyslogger) Syslog(p pri.Priority, msg interface{}) error {
+	return nil
+}
+
+func (l *lifecycleSyslogger) Flush() error {
+	l.Flushes++
+	return nil
+}
+
+func (l *lifecycleSyslogger) Close() error {
+	l.Closes++
+	return nil
+}
+
+type errorCloseSyslogger struct {
+	errorCloser
+}
+
+func (e *errorCloseSyslogger) Syslog(p pri.Priority, msg interface{}) error {
+	return nil
+}
+
+type lastSyslogger struct {
+	P pri.Priority
+	M interface{}
+}
+
+func (l *lastSyslogger) Syslog(p pri.Priority, msg interface{}) error {
+	l.P = p
+	l.M = msg
+	return nil
+}
+
+type fieldError struct {
+	S string
+	F map[string]interface{}
+}
+
+func (f *fieldError) Error() string {
+	return f.S
+}
+
+func (f *fieldError) Fields() map[string]interface{} {
+	return f.F
+}
+
+type stackError struct {
+	S   string
+	PCs []uintptr
+}
+
+func (s *stackError) Error() string {
+	return s.S
+}
+
+func (s *stackError) Callers() []uintptr {
+	return s.PCs
+}
+
+type causeError struct {
+	S     string
+	Cause error
+}
+
+func (c *causeError) Error() string {
+	return c.S
+}
+
+func (c *causeError) Unwrap() error {
+	return c.Cause
+}
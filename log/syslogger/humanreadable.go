@@ -11,12 +11,24 @@ import (
 
 // HumanReadable is a syslogger.Syslogger that will format the message in a
 // human readable way before passing the modified message to another
-// syslogger.Syslogger.
+// syslogger.Syslogger. The formatted message is given as a []byte which is only
+// valid until the other syslogger.Syslogger returns. An error which wraps other
+// errors is expanded, with a line for each layer of the chain giving its type,
+// the part of the message it added, and any fields or stack it captured.
 type HumanReadable struct {
 	Syslogger Syslogger
 	Ident     string
 	Facility  pri.Priority
 	Pid       bool
+
+	// Caller puts the source file, line, and function of the code which
+	// logged each message at the start of the content. It must not be used
+	// behind a NoWait, which logs from a different goroutine.
+	Caller bool
+
+	hc headerCache
+
+	co closeOnce
 }
 
 // Syslog logs a message. In the case of HumanReadable, the message will be
@@ -26,20 +38,26 @@ func (h *HumanReadable) Syslog(p pri.Priority, msg interface{}) error {
 	switch msg := msg.(type) {
 	case string:
 		s = msg
+	case []byte:
+		s = string(msg)
 	case fmt.Stringer:
 		s = msg.String()
 	case error:
-		s = msg.Error()
+		s = errorText(msg)
 	default:
 		return errors.New(
 			"The *syslogger.HumanReadable expects the message" +
-				" argument to have the type string," +
+				" argument to have the type string, []byte," +
 				" fmt.Stringer, or error, but the given" +
 				" message argument does not have one of" +
 				" these types.",
 		)
 	}
 
+	if h.Caller {
+		s = callerPrefix() + s
+	}
+
 	if p.ValidFacility() != nil || p.Facility() == 0x00 {
 		if h.Facility == 0x00 {
 			p = pri.User | p.Severity()
@@ -48,11 +66,27 @@ func (h *HumanReadable) Syslog(p pri.Priority, msg interface{}) error {
 		}
 	}
 
-	timestamp := time.Now().Format(time.UnixDate)
+	buf := getBuffer()
+	defer putBuffer(buf)
+
+	now := timeNow()
+	b := append((*buf)[:0], p.Facility().String()...)
+	b = append(b, ' ')
+	b = append(b, p.Severity().String()...)
+	b = append(b, ' ')
+	b = now.AppendFormat(b, time.UnixDate)
+	b = append(b, h.header(now)...)
+	b = append(b, s...)
 
-	hostname, e := osHostname()
-	if e != nil {
-		hostname = "localhost"
+	return forward(h.Syslogger, 0x00, buf, b)
+}
+
+// header gives the part of the message which follows the timestamp and comes
+// before the content, which is only built again when the hostname is due to be
+// looked up again.
+func (h *HumanReadable) header(now time.Time) []byte {
+	if b := h.hc.load(now, h.Ident, h.Pid); b != nil {
+		return b
 	}
 
 	ident := h.Ident
@@ -60,23 +94,25 @@ func (h *HumanReadable) Syslog(p pri.Priority, msg interface{}) error {
 		ident = os.Args[0]
 	}
 
+	b := []byte(" " + hostname(false) + " " + ident)
 	if h.Pid {
-		ident = fmt.Sprintf(
-			"%s[%d]",
-			ident,
-			os.Getpid(),
-		)
+		b = fmt.Appendf(b, "[%d]", os.Getpid())
 	}
+	b = append(b, ' ')
+
+	h.hc.store(now, h.Ident, h.Pid, b)
+	return b
+}
+
+// Flush flushes the other syslogger.Syslogger.
+func (h *HumanReadable) Flush() error {
+	return flushAll(h.Syslogger)
+}
 
-	m := fmt.Sprintf(
-		"%s %s %s %s %s %s",
-		p.Facility(),
-		p.Severity(),
-		timestamp,
-		hostname,
-		ident,
-		s,
-	)
-
-	return h.Syslogger.Syslog(pri.Priority(0x0), m)
+// Close closes the other syslogger.Syslogger, only the first time it is
+// called.
+func (h *HumanReadable) Close() error {
+	return h.co.close(func() error {
+		return closeAll(h.Syslogger)
+	})
 }
@@ -1,6 +1,7 @@
 package syslogger
 
 import (
+	"io/ioutil"
 	"regexp"
 	"testing"
 
@@ -157,3 +158,24 @@ func TestHumanReadableSyslog(t *testing.T) {
 		osHostname = origOsHostname
 	}
 }
+
+func TestHumanReadableAllocs(t *testing.T) {
+	h := &HumanReadable{
+		Syslogger: &Writer{Writer: ioutil.Discard},
+		Ident:     "allocs",
+		Pid:       true,
+	}
+
+	// The only allocation left is for passing the formatted []byte as an
+	// interface{} to the other syslogger.Syslogger.
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = h.Syslog(pri.Info, "message")
+	})
+	assert.True(
+		t,
+		allocs <= 1,
+		"HumanReadable allocation test expects at most 1 allocation"+
+			" per message, but there were %v.",
+		allocs,
+	)
+}
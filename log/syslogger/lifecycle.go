@@ -0,0 +1,74 @@
+package syslogger
+
+import (
+	"io"
+	"reflect"
+	"sync"
+)
+
+// closeOnce makes the Close function of a syslogger.Syslogger safe to call more
+// than once, so that a syslogger.Syslogger shared by several others only
+// closes the ones it wraps a single time.
+type closeOnce struct {
+	once sync.Once
+	err  error
+}
+
+func (c *closeOnce) close(f func() error) error {
+	c.once.Do(func() {
+		c.err = f()
+	})
+
+	return c.err
+}
+
+// closeAll closes each of the given syslogger.Sysloggers which has a Close
+// function (skipping any which appear more than once), and gives the first
+// error.
+func closeAll(ss ...Syslogger) error {
+	var err error
+
+	for i, s := range ss {
+		if c, ok := s.(io.Closer); ok && !repeated(ss, i) {
+			if e := c.Close(); err == nil {
+				err = e
+			}
+		}
+	}
+
+	return err
+}
+
+// flushAll flushes each of the given syslogger.Sysloggers which has a Flush
+// function (skipping any which appear more than once), and gives the first
+// error.
+func flushAll(ss ...Syslogger) error {
+	var err error
+
+	for i, s := range ss {
+		if f, ok := s.(Flusher); ok && !repeated(ss, i) {
+			if e := f.Flush(); err == nil {
+				err = e
+			}
+		}
+	}
+
+	return err
+}
+
+// repeated indicates whether the syslogger.Syslogger at the given index also
+// appears earlier in the list, without panicking if it can't be compared.
+func repeated(ss []Syslogger, i int) bool {
+	t := reflect.TypeOf(ss[i])
+	if t == nil || !t.Comparable() {
+		return false
+	}
+
+	for _, s := range ss[:i] {
+		if reflect.TypeOf(s) == t && s == ss[i] {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,384 @@
+package syslogger
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/proidiot/gone/log/pri"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLifecycle(t *testing.T) {
+	type testCase struct {
+		inputWrap       func(Syslogger) Syslogger
+		expectedFlushes int
+		expectedCloses  int
+	}
+
+	tests := map[string]testCase{
+		"CircuitBreaker": {
+			inputWrap: func(s Syslogger) Syslogger {
+				return &CircuitBreaker{Syslogger: s}
+			},
+			expectedFlushes: 1,
+			expectedCloses:  1,
+		},
+		"FacilityMask": {
+			inputWrap: func(s Syslogger) Syslogger {
+				return &FacilityMask{Syslogger: s}
+			},
+			expectedFlushes: 1,
+			expectedCloses:  1,
+		},
+		"Fallthrough": {
+			inputWrap: func(s Syslogger) Syslogger {
+				return &Fallthrough{Default: s, Fallthrough: s}
+			},
+			expectedFlushes: 1,
+			expectedCloses:  1,
+		},
+		"Filter": {
+			inputWrap: func(s Syslogger) Syslogger {
+				return &Filter{Syslogger: s}
+			},
+			expectedFlushes: 1,
+			expectedCloses:  1,
+		},
+		"HumanReadable": {
+			inputWrap: func(s Syslogger) Syslogger {
+				return &HumanReadable{Syslogger: s}
+			},
+			expectedFlushes: 1,
+			expectedCloses:  1,
+		},
+		"Multi": {
+			inputWrap: func(s Syslogger) Syslogger {
+				return &Multi{Sysloggers: []Syslogger{s, s}}
+			},
+			expectedFlushes: 1,
+			expectedCloses:  1,
+		},
+		"Multiline": {
+			inputWrap: func(s Syslogger) Syslogger {
+				return &Multiline{Syslogger: s}
+			},
+			expectedFlushes: 1,
+			expectedCloses:  1,
+		},
+		"Newliner": {
+			inputWrap: func(s Syslogger) Syslogger {
+				return &Newliner{Syslogger: s}
+			},
+			expectedFlushes: 1,
+			expectedCloses:  1,
+		},
+		"NoWait": {
+			inputWrap: func(s Syslogger) Syslogger {
+				return &NoWait{Syslogger: s}
+			},
+			expectedFlushes: 1,
+			expectedCloses:  1,
+		},
+		"Pool": {
+			inputWrap: func(s Syslogger) Syslogger {
+				return &Pool{Sysloggers: []Syslogger{s}}
+			},
+			expectedFlushes: 1,
+			expectedCloses:  1,
+		},
+		"Retry": {
+			inputWrap: func(s Syslogger) Syslogger {
+				return &Retry{Syslogger: s}
+			},
+			expectedFlushes: 1,
+			expectedCloses:  1,
+		},
+		"Rfc3164": {
+			inputWrap: func(s Syslogger) Syslogger {
+				return &Rfc3164{Syslogger: s}
+			},
+			expectedFlushes: 1,
+			expectedCloses:  1,
+		},
+		"Router": {
+			inputWrap: func(s Syslogger) Syslogger {
+				return &Router{
+					Routes:  []Route{{Syslogger: s}},
+					Default: s,
+				}
+			},
+			expectedFlushes: 1,
+			expectedCloses:  1,
+		},
+		"Sanitizer": {
+			inputWrap: func(s Syslogger) Syslogger {
+				return &Sanitizer{Syslogger: s}
+			},
+			expectedFlushes: 1,
+			expectedCloses:  1,
+		},
+		"SeverityMask": {
+			inputWrap: func(s Syslogger) Syslogger {
+				return &SeverityMask{Syslogger: s}
+			},
+			expectedFlushes: 1,
+			expectedCloses:  1,
+		},
+		"Spool": {
+			inputWrap: func(s Syslogger) Syslogger {
+				return &Spool{Syslogger: s, Dir: t.TempDir()}
+			},
+			expectedFlushes: 1,
+			expectedCloses:  1,
+		},
+		"Stacktrace": {
+			inputWrap: func(s Syslogger) Syslogger {
+				return &Stacktrace{Syslogger: s}
+			},
+			expectedFlushes: 1,
+			expectedCloses:  1,
+		},
+		"TimedMask": {
+			inputWrap: func(s Syslogger) Syslogger {
+				return &TimedMask{Syslogger: s}
+			},
+			expectedFlushes: 1,
+			expectedCloses:  1,
+		},
+		"uncreated Delay": {
+			inputWrap: func(s Syslogger) Syslogger {
+				d, _ := NewDelay(func() (Syslogger, error) {
+					return s, nil
+				})
+				return d
+			},
+		},
+		"created Delay": {
+			inputWrap: func(s Syslogger) Syslogger {
+				d, _ := NewDelay(func() (Syslogger, error) {
+					return s, nil
+				})
+				_ = d.Syslog(pri.Priority(0x0), "message")
+				return d
+			},
+			expectedFlushes: 1,
+			expectedCloses:  1,
+		},
+	}
+
+	for explanation, test := range tests {
+		ls := &lifecycleSyslogger{}
+		s := test.inputWrap(ls)
+
+		f, ok := s.(Flusher)
+		assert.True(
+			t,
+			ok,
+			"Lifecycle test expects a Flush function for: %s",
+			explanation,
+		)
+		c, ok := s.(io.Closer)
+		assert.True(
+			t,
+			ok,
+			"Lifecycle test expects a Close function for: %s",
+			explanation,
+		)
+		if f == nil || c == nil {
+			continue
+		}
+
+		assert.NoError(
+			t,
+			f.Flush(),
+			"Lifecycle test expects no error on Flush for: %s",
+			explanation,
+		)
+		assert.NoError(
+			t,
+			c.Close(),
+			"Lifecycle test expects no error on Close for: %s",
+			explanation,
+		)
+		assert.NoError(
+			t,
+			c.Close(),
+			"Lifecycle test expects no error on a second Close"+
+				" for: %s",
+			explanation,
+		)
+
+		assert.Equal(
+			t,
+			test.expectedFlushes,
+			ls.Flushes,
+			"Lifecycle test expects a different number of flushes"+
+				" for: %s",
+			explanation,
+		)
+		assert.Equal(
+			t,
+			test.expectedCloses,
+			ls.Closes,
+			"Lifecycle test expects a different number of closes"+
+				" for: %s",
+			explanation,
+		)
+	}
+}
+
+func TestLifecycleShared(t *testing.T) {
+	ls := &lifecycleSyslogger{}
+	shared := &SeverityMask{Syslogger: ls}
+
+	m := &Multi{
+		Sysloggers: []Syslogger{
+			shared,
+			&Filter{Syslogger: shared},
+			&Newliner{Syslogger: shared},
+		},
+	}
+
+	assert.NoError(
+		t,
+		m.Close(),
+		"Lifecycle shared test expects no error on Close.",
+	)
+	assert.Equal(
+		t,
+		1,
+		ls.Closes,
+		"Lifecycle shared test expects a shared syslogger.Syslogger to"+
+			" be closed once.",
+	)
+}
+
+func TestLifecycleCloseError(t *testing.T) {
+	before, after := &lifecycleSyslogger{}, &lifecycleSyslogger{}
+	m := &Multi{
+		Sysloggers: []Syslogger{
+			before,
+			&errorCloseSyslogger{},
+			after,
+		},
+	}
+
+	e := m.Close()
+	assert.Error(
+		t,
+		e,
+		"Lifecycle close error test expects the error to be given.",
+	)
+	assert.Equal(
+		t,
+		[]int{1, 1},
+		[]int{before.Closes, after.Closes},
+		"Lifecycle close error test expects every other"+
+			" syslogger.Syslogger to be closed despite the error.",
+	)
+	assert.Equal(
+		t,
+		e,
+		m.Close(),
+		"Lifecycle close error test expects a second Close to give"+
+			" the same error.",
+	)
+}
+
+func TestWriterFlush(t *testing.T) {
+	var b bytes.Buffer
+	bw := bufio.NewWriter(&b)
+	w := &Writer{Writer: bw}
+
+	_ = w.Syslog(pri.Priority(0x0), "message")
+	assert.Empty(
+		t,
+		b.String(),
+		"Writer flush test expects the message to be buffered.",
+	)
+
+	assert.NoError(
+		t,
+		w.Flush(),
+		"Writer flush test expects no error on Flush.",
+	)
+	assert.Equal(
+		t,
+		"message",
+		b.String(),
+		"Writer flush test expects the message to be flushed.",
+	)
+}
+
+func TestOwnerClose(t *testing.T) {
+	type testCase struct {
+		inputCloser    *countCloser
+		inputSyslogger func(io.Closer) io.Closer
+		expectedCloses int
+	}
+
+	tests := map[string]testCase{
+		"Writer without closer": {
+			inputSyslogger: func(c io.Closer) io.Closer {
+				return &Writer{Writer: &bytes.Buffer{}}
+			},
+		},
+		"Writer with closer": {
+			inputCloser: &countCloser{},
+			inputSyslogger: func(c io.Closer) io.Closer {
+				return &Writer{
+					Writer: &bytes.Buffer{},
+					Closer: c,
+				}
+			},
+			expectedCloses: 1,
+		},
+		"Console without closer": {
+			inputSyslogger: func(c io.Closer) io.Closer {
+				return &Console{}
+			},
+		},
+		"Console with closer": {
+			inputCloser: &countCloser{},
+			inputSyslogger: func(c io.Closer) io.Closer {
+				return &Console{Closer: c}
+			},
+			expectedCloses: 1,
+		},
+	}
+
+	for explanation, test := range tests {
+		var c io.Closer
+		if test.inputCloser != nil {
+			c = test.inputCloser
+		}
+		s := test.inputSyslogger(c)
+
+		assert.NoError(
+			t,
+			s.Close(),
+			"Owner close test expects no error for: %s",
+			explanation,
+		)
+		assert.NoError(
+			t,
+			s.Close(),
+			"Owner close test expects no error on a second Close"+
+				" for: %s",
+			explanation,
+		)
+
+		if test.inputCloser != nil {
+			assert.Equal(
+				t,
+				test.expectedCloses,
+				test.inputCloser.N,
+				"Owner close test expects the closer to be"+
+					" closed once for: %s",
+				explanation,
+			)
+		}
+	}
+}
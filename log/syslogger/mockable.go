@@ -3,8 +3,17 @@ package syslogger
 import (
 	"log/syslog"
 	"os"
+	"time"
 )
 
 var osHostname = os.Hostname
 
 var syslogNew = syslog.New
+
+var osIsTerminal = isTerminal
+
+var timeNow = time.Now
+
+var timeSleep = time.Sleep
+
+var timeAfterFunc = time.AfterFunc
@@ -9,6 +9,8 @@ import (
 type Multi struct {
 	Sysloggers []Syslogger
 	TryAll     bool
+
+	co closeOnce
 }
 
 // Syslog logs a message. In the case of Multi, the message will be sent to each
@@ -29,3 +31,16 @@ func (m *Multi) Syslog(p pri.Priority, msg interface{}) error {
 
 	return err
 }
+
+// Flush flushes the other syslogger.Sysloggers.
+func (m *Multi) Flush() error {
+	return flushAll(m.Sysloggers...)
+}
+
+// Close closes the other syslogger.Sysloggers, only the first time it is
+// called.
+func (m *Multi) Close() error {
+	return m.co.close(func() error {
+		return closeAll(m.Sysloggers...)
+	})
+}
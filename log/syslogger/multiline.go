@@ -0,0 +1,121 @@
+package syslogger
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/proidiot/gone/errors"
+	"github.com/proidiot/gone/log/pri"
+)
+
+// MultilineMarker is the default continuation marker placed at the start of
+// every line but the first when a Multiline splits a message.
+const MultilineMarker = "+ "
+
+// MultilinePolicy describes how a Multiline handles messages that contain
+// embedded newlines.
+type MultilinePolicy byte
+
+const (
+	// MultilineEscape replaces each embedded newline with the octal escape
+	// sequence #012, as rsyslog does. This is the default policy.
+	MultilineEscape MultilinePolicy = iota
+
+	// MultilineSplit sends each line of the message as a separate record,
+	// with every line but the first prefixed by a continuation marker.
+	MultilineSplit
+
+	// MultilineIntact leaves the message alone, which is only appropriate
+	// for transports that frame messages by length rather than by newline
+	// (such as octet-counted TCP).
+	MultilineIntact
+)
+
+// Multiline is a syslogger.Syslogger that applies a MultilinePolicy to messages
+// containing embedded newlines (such as stack traces) before passing them to
+// another syslogger.Syslogger. It is meant to be placed in front of a
+// formatting syslogger.Syslogger such as Rfc3164 or HumanReadable so that every
+// resulting record is framed consistently. An error which wraps other errors is
+// expanded in the same way as by HumanReadable, so each layer of the chain gets
+// its own line.
+type Multiline struct {
+	Syslogger Syslogger
+	Policy    MultilinePolicy
+
+	// Marker is the continuation marker used by MultilineSplit, and an
+	// empty Marker means MultilineMarker.
+	Marker string
+
+	co closeOnce
+}
+
+// Syslog logs a message. In the case of Multiline, a message containing
+// embedded newlines is escaped or split (according to the MultilinePolicy)
+// before being passed to another syslogger.Syslogger. A single trailing newline
+// is not considered to be embedded.
+func (m *Multiline) Syslog(p pri.Priority, msg interface{}) error {
+	if m.Policy == MultilineIntact {
+		return m.Syslogger.Syslog(p, msg)
+	}
+
+	var s string
+	switch msg := msg.(type) {
+	case string:
+		s = msg
+	case []byte:
+		s = string(msg)
+	case fmt.Stringer:
+		s = msg.String()
+	case error:
+		s = errorText(msg)
+	default:
+		return errors.New(
+			"The *syslogger.Multiline expects the message" +
+				" argument to have the type string, []byte," +
+				" fmt.Stringer, or error, but the given" +
+				" message argument does not have one of" +
+				" these types.",
+		)
+	}
+
+	s = strings.TrimSuffix(s, "\n")
+	if !strings.Contains(s, "\n") {
+		return m.Syslogger.Syslog(p, msg)
+	}
+
+	if m.Policy != MultilineSplit {
+		s = strings.Replace(s, "\n", "#012", -1)
+		return m.Syslogger.Syslog(p, s)
+	}
+
+	marker := m.Marker
+	if marker == "" {
+		marker = MultilineMarker
+	}
+
+	for i, l := range strings.Split(s, "\n") {
+		l = strings.TrimSuffix(l, "\r")
+		if i != 0 {
+			l = marker + l
+		}
+
+		if e := m.Syslogger.Syslog(p, l); e != nil {
+			return e
+		}
+	}
+
+	return nil
+}
+
+// Flush flushes the other syslogger.Syslogger.
+func (m *Multiline) Flush() error {
+	return flushAll(m.Syslogger)
+}
+
+// Close closes the other syslogger.Syslogger, only the first time it is
+// called.
+func (m *Multiline) Close() error {
+	return m.co.close(func() error {
+		return closeAll(m.Syslogger)
+	})
+}
@@ -0,0 +1,100 @@
+package syslogger
+
+import (
+	"testing"
+
+	"github.com/proidiot/gone/errors"
+	"github.com/proidiot/gone/log/pri"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultilineSyslog(tt *testing.T) {
+	tests := map[string]struct {
+		inputPolicy     MultilinePolicy
+		inputMarker     string
+		inputMsg        interface{}
+		expectedError   bool
+		expectedOutputs []string
+	}{
+		"zero values": {
+			inputMsg:      nil,
+			expectedError: true,
+		},
+		"bytes": {
+			inputMsg:        []byte("a\nb"),
+			expectedOutputs: []string{"a#012b"},
+		},
+		"single line": {
+			inputMsg:        "testing",
+			expectedOutputs: []string{"testing"},
+		},
+		"trailing newline": {
+			inputMsg:        "testing\n",
+			expectedOutputs: []string{"testing\n"},
+		},
+		"escape": {
+			inputMsg:        "testing\n123\n",
+			expectedOutputs: []string{"testing#012123"},
+		},
+		"escape stringer": {
+			inputMsg:        &stringer{"a\nb"},
+			expectedOutputs: []string{"a#012b"},
+		},
+		"escape error": {
+			inputMsg:        errors.New("a\nb"),
+			expectedOutputs: []string{"a#012b"},
+		},
+		"split": {
+			inputPolicy: MultilineSplit,
+			inputMsg: "panic: oops\n\nmain.main()\r\n" +
+				"\tmain.go:5",
+			expectedOutputs: []string{
+				"panic: oops",
+				MultilineMarker,
+				MultilineMarker + "main.main()",
+				MultilineMarker + "\tmain.go:5",
+			},
+		},
+		"split custom marker": {
+			inputPolicy: MultilineSplit,
+			inputMarker: "... ",
+			inputMsg:    "a\nb\n",
+			expectedOutputs: []string{
+				"a",
+				"... b",
+			},
+		},
+		"split single line": {
+			inputPolicy:     MultilineSplit,
+			inputMsg:        "testing",
+			expectedOutputs: []string{"testing"},
+		},
+		"intact": {
+			inputPolicy:     MultilineIntact,
+			inputMsg:        "a\nb\n",
+			expectedOutputs: []string{"a\nb\n"},
+		},
+	}
+
+	for explanation, test := range tests {
+		tt.Run(explanation, func(t *testing.T) {
+			rec := new(recordAllSyslogger)
+
+			multiline := &Multiline{
+				Syslogger: rec,
+				Policy:    test.inputPolicy,
+				Marker:    test.inputMarker,
+			}
+
+			actualError := multiline.Syslog(pri.Info, test.inputMsg)
+
+			if test.expectedError {
+				assert.Error(t, actualError)
+			} else {
+				assert.NoError(t, actualError)
+			}
+
+			assert.Equal(t, test.expectedOutputs, rec.M)
+		})
+	}
+}
@@ -16,32 +16,22 @@ type NativeSyslog struct {
 }
 
 // Syslog logs a message. In the case of NativeSyslog, the message is sent to
-// golang's log/syslog.Writer.
+// golang's log/syslog.Writer. The message may be a string, []byte,
+// fmt.Stringer, or error, and an error which wraps other errors is expanded in
+// the same way as by HumanReadable.
 func (n *NativeSyslog) Syslog(p pri.Priority, msg interface{}) error {
-	m, goodType := msg.(string)
+	m, goodType := messageText(msg)
 	if !goodType {
 		return errors.New(
 			"The native Go log/syslog system only accepts" +
-				" strings as a message, but a non-string" +
-				" message was given.",
+				" strings, []byte, fmt.Stringer, or error" +
+				" values as a message, but a message of" +
+				" some other type was given.",
 		)
 	}
 
 	if p.Facility() != 0x00 && p.Facility() != n.f {
-		return fmt.Errorf(
-			"The native Go log/syslog system does not provide a"+
-				" mechanism for changing log facilities of an"+
-				" existing *syslog.Writer, but the"+
-				" pri.Facility this *syslogger.NativeSyslog"+
-				" was created with does not match the"+
-				" pri.Facility component of the given"+
-				" pr.Priority. This *syslogger.NativeSyslog"+
-				" was created with pri.Facility %s, but the"+
-				" given pri.Priority argument has"+
-				" pri.Facility: %s",
-			n.f,
-			p.Facility(),
-		)
+		return &nativeFacilityError{n.f, p.Facility()}
 	}
 
 	switch p.Severity() {
@@ -64,6 +54,29 @@ func (n *NativeSyslog) Syslog(p pri.Priority, msg interface{}) error {
 	}
 }
 
+// nativeFacilityError is given by NativeSyslog for a message with a
+// pri.Facility other than its own, which (unlike most of its errors) says
+// nothing about the health of syslogd.
+type nativeFacilityError struct {
+	f     pri.Priority
+	given pri.Priority
+}
+
+func (e *nativeFacilityError) Error() string {
+	return fmt.Sprintf(
+		"The native Go log/syslog system does not provide a"+
+			" mechanism for changing log facilities of an"+
+			" existing *syslog.Writer, but the pri.Facility"+
+			" this *syslogger.NativeSyslog was created with"+
+			" does not match the pri.Facility component of the"+
+			" given pr.Priority. This *syslogger.NativeSyslog"+
+			" was created with pri.Facility %s, but the given"+
+			" pri.Priority argument has pri.Facility: %s",
+		e.f,
+		e.given,
+	)
+}
+
 // Close closes a native log/syslog.Writer.
 func (n *NativeSyslog) Close() error {
 	return n.w.Close()
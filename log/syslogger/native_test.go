@@ -473,6 +473,26 @@ func TestNativeSyslog(t *testing.T) {
 			inputMsg:      "debug msg",
 			expectedError: false,
 		},
+		"error": {
+			inputPriority: pri.Err,
+			inputMsg:      errors.New("error msg"),
+			expectedError: false,
+		},
+		"stringer": {
+			inputPriority: pri.Info,
+			inputMsg:      &stringer{"stringer msg"},
+			expectedError: false,
+		},
+		"byte slice": {
+			inputPriority: pri.Info,
+			inputMsg:      []byte("byte slice msg"),
+			expectedError: false,
+		},
+		"unsupported type": {
+			inputPriority: pri.Info,
+			inputMsg:      42,
+			expectedError: true,
+		},
 		"combined priority": {
 			inputPriority: pri.Syslog | pri.Notice,
 			inputMsg:      "combined priority msg",
@@ -522,7 +542,7 @@ func TestNativeSyslog(t *testing.T) {
 			)
 
 			var expectedRegex *regexp.Regexp
-			if s, ok := test.inputMsg.(string); ok {
+			if s, ok := messageText(test.inputMsg); ok {
 				expectedRegex = regexp.MustCompile(
 					fmt.Sprintf(
 						"^<%d>.*%s$",
@@ -554,4 +574,53 @@ func TestNativeSyslog(t *testing.T) {
 			)
 		}
 	}
+
+	// Messages which NativeSyslog can log must not count as failures of
+	// the destination, or a CircuitBreaker would be opened by them.
+	ra := &recordAllSyslogger{}
+	cb := &CircuitBreaker{Syslogger: n}
+	f := &Fallthrough{Default: cb, Fallthrough: ra}
+	for i := 0; i <= DefaultCircuitBreakerThreshold; i++ {
+		assert.NoError(
+			t,
+			f.Syslog(pri.Err, errors.New("error msg")),
+			"NativeSyslog test expects no error from an error"+
+				" message behind a CircuitBreaker.",
+		)
+		<-comm
+	}
+	assert.False(
+		t,
+		cb.Open(),
+		"NativeSyslog test expects error messages to leave a"+
+			" CircuitBreaker closed.",
+	)
+	assert.Empty(
+		t,
+		ra.M,
+		"NativeSyslog test expects error messages not to fall"+
+			" through.",
+	)
+
+	// HumanReadable passes its formatted message on as a []byte.
+	h := &HumanReadable{Syslogger: n, Ident: "human"}
+	assert.NoError(
+		t,
+		h.Syslog(pri.Err, "human msg"),
+		"NativeSyslog test expects no error behind a HumanReadable.",
+	)
+	listenerResponse := <-comm
+	require.NotNil(
+		t,
+		listenerResponse,
+		"NativeSyslog test expects non-nil listener response behind"+
+			" a HumanReadable.",
+	)
+	assert.Regexp(
+		t,
+		regexp.MustCompile(` human human msg$`),
+		listenerResponse.S,
+		"NativeSyslog test expects the message from a HumanReadable"+
+			" to be logged.",
+	)
 }
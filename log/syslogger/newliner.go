@@ -1,6 +1,7 @@
 package syslogger
 
 import (
+	"bytes"
 	"fmt"
 	"strings"
 
@@ -12,6 +13,8 @@ import (
 // a newline (i.e. a literal byte 0x0A).
 type Newliner struct {
 	Syslogger Syslogger
+
+	co closeOnce
 }
 
 // Syslog logs a message. In the case of Newliner, the message has a newline
@@ -23,11 +26,24 @@ func (n *Newliner) Syslog(p pri.Priority, msg interface{}) error {
 		s = m.String()
 	case string:
 		s = m
+	case []byte:
+		if bytes.HasSuffix(m, []byte("\n")) {
+			return n.Syslogger.Syslog(p, m)
+		}
+
+		// The message can't be appended to in place, since it
+		// belongs to the caller.
+		buf := getBuffer()
+		defer putBuffer(buf)
+
+		b := append(append(*buf, m...), '\n')
+		return forward(n.Syslogger, p, buf, b)
 	default:
 		return errors.New(
 			"The *syslogger.Newliner does not support message" +
-				" types other than fmt.Stringer and string," +
-				" but the given message has a different type.",
+				" types other than fmt.Stringer, string, and" +
+				" []byte, but the given message has a" +
+				" different type.",
 		)
 	}
 
@@ -37,3 +53,16 @@ func (n *Newliner) Syslog(p pri.Priority, msg interface{}) error {
 
 	return n.Syslogger.Syslog(p, s+"\n")
 }
+
+// Flush flushes the other syslogger.Syslogger.
+func (n *Newliner) Flush() error {
+	return flushAll(n.Syslogger)
+}
+
+// Close closes the other syslogger.Syslogger, only the first time it is
+// called.
+func (n *Newliner) Close() error {
+	return n.co.close(func() error {
+		return closeAll(n.Syslogger)
+	})
+}
@@ -15,13 +15,21 @@ func TestNewlinerSyslog(tt *testing.T) {
 		expectedOutput string
 	}{
 		"zero values": {
-			inputPri:      pri.Priority(0x00),
-			inputMsg:      []byte{},
-			expectedError: true,
+			inputPri:       pri.Priority(0x00),
+			inputMsg:       []byte{},
+			expectedOutput: "\n",
 		},
 		"full values": {
-			inputPri:      pri.Priority(0xFF),
-			inputMsg:      []byte{0xFF, 0xFF, 0xFF, 0xFF},
+			inputPri:       pri.Priority(0xFF),
+			inputMsg:       []byte{0xFF, 0xFF, 0xFF, 0xFF},
+			expectedOutput: "\xFF\xFF\xFF\xFF\n",
+		},
+		"bytes with newline": {
+			inputMsg:       []byte("testing\n"),
+			expectedOutput: "testing\n",
+		},
+		"unsupported type": {
+			inputMsg:      42,
 			expectedError: true,
 		},
 		"no newline": {
@@ -1,14 +1,20 @@
 package syslogger
 
 import (
+	"sync"
+
 	"github.com/proidiot/gone/errors"
 	"github.com/proidiot/gone/log/pri"
 )
 
 // NoWait is a syslogger.Syslogger that allows calls to Syslog to return
-// immediately.
+// immediately. Flush and Close wait for any messages which are still being
+// logged.
 type NoWait struct {
 	Syslogger Syslogger
+
+	wg sync.WaitGroup
+	co closeOnce
 }
 
 // Syslog logs a message. In the case of NoWait, the message will be sent to
@@ -23,8 +29,31 @@ func (n *NoWait) Syslog(p pri.Priority, msg interface{}) error {
 		)
 	}
 
+	// A []byte message is only valid until this call returns.
+	if b, ok := msg.([]byte); ok {
+		msg = append([]byte(nil), b...)
+	}
+
+	n.wg.Add(1)
 	go func() {
+		defer n.wg.Done()
 		_ = n.Syslogger.Syslog(p, msg)
 	}()
 	return nil
 }
+
+// Flush waits for any messages which are still being logged, and then flushes
+// the other syslogger.Syslogger.
+func (n *NoWait) Flush() error {
+	n.wg.Wait()
+	return flushAll(n.Syslogger)
+}
+
+// Close waits for any messages which are still being logged, and then closes
+// the other syslogger.Syslogger, only the first time it is called.
+func (n *NoWait) Close() error {
+	return n.co.close(func() error {
+		n.wg.Wait()
+		return closeAll(n.Syslogger)
+	})
+}
@@ -58,6 +58,7 @@ func TestNoWaitSyslog(t *testing.T) {
 
 		if test.inputSyslogger == sfs {
 			sc <- nil
+			_ = n.Flush()
 			actualCall := sfs.Flag
 
 			assert.Equal(
@@ -70,3 +71,26 @@ func TestNoWaitSyslog(t *testing.T) {
 		}
 	}
 }
+
+func TestNoWaitCopiesBytes(t *testing.T) {
+	rc := make(chan string)
+	n := &NoWait{
+		Syslogger: &chanSyslogger{rc},
+	}
+
+	b := []byte("message")
+	assert.NoError(
+		t,
+		n.Syslog(pri.Priority(0x0), b),
+		"NoWait bytes test expects no error.",
+	)
+	copy(b, "changed")
+
+	assert.Equal(
+		t,
+		"message",
+		<-rc,
+		"NoWait bytes test expects the message to be copied before"+
+			" the call returns.",
+	)
+}
@@ -0,0 +1,223 @@
+package syslogger
+
+import (
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"github.com/proidiot/gone/errors"
+	"github.com/proidiot/gone/log/pri"
+)
+
+// DefaultPoolProbeInterval is the length of time a Pool which has not been
+// given a probe interval waits before trying an unhealthy destination again.
+const DefaultPoolProbeInterval = 30 * time.Second
+
+// PoolStrategy describes how a Pool chooses the destination for a message.
+type PoolStrategy byte
+
+const (
+	// PoolFailover sends every message to the first healthy destination,
+	// in order. This is the default strategy.
+	PoolFailover PoolStrategy = iota
+
+	// PoolRoundRobin spreads messages evenly across the healthy
+	// destinations.
+	PoolRoundRobin
+
+	// PoolHashIdent sends every message to a destination chosen by hashing
+	// the Pool Ident, so that all processes with the same identity stick to
+	// the same destination while it is healthy.
+	PoolHashIdent
+)
+
+// PoolHealth describes the health of a single destination of a Pool.
+type PoolHealth struct {
+	// Healthy indicates whether the last attempt to log to the destination
+	// succeeded. A destination which has not been tried is healthy.
+	Healthy bool
+
+	// Failures is the number of consecutive failed attempts.
+	Failures int
+
+	// LastError is the error given by the last failed attempt.
+	LastError error
+
+	// LastCheck is the time of the last attempt.
+	LastCheck time.Time
+}
+
+// Pool is a syslogger.Syslogger that spreads messages across several other
+// syslogger.Sysloggers (typically connections to highly available collectors)
+// according to a PoolStrategy. A destination which fails is considered
+// unhealthy and is skipped until the probe interval has passed, at which point
+// it is tried again. If every destination is unhealthy then they are all tried
+// anyway as a last resort. Unlike Multi, each message is logged to only one
+// destination.
+type Pool struct {
+	Sysloggers []Syslogger
+	Strategy   PoolStrategy
+	Ident      string
+
+	// ProbeInterval is the length of time an unhealthy destination is
+	// skipped, and zero means DefaultPoolProbeInterval.
+	ProbeInterval time.Duration
+
+	health []PoolHealth
+	next   int
+	x      sync.Mutex
+
+	co closeOnce
+}
+
+var poolDialNativeSyslog = DialNativeSyslog
+
+// Syslog logs a message. In the case of Pool, the message is sent to one of
+// several other syslogger.Sysloggers, trying each in turn until one succeeds.
+func (pl *Pool) Syslog(p pri.Priority, msg interface{}) error {
+	n := len(pl.Sysloggers)
+	if n == 0 {
+		return errors.New(
+			"A syslogger.Pool must have at least one syslogger in" +
+				" order to be meaningful, but an attempt has" +
+				" been made to write a log to a" +
+				" syslogger.Pool without any sysloggers.",
+		)
+	}
+
+	probeInterval := pl.ProbeInterval
+	if probeInterval <= 0 {
+		probeInterval = DefaultPoolProbeInterval
+	}
+
+	pl.x.Lock()
+	pl.init()
+
+	start := 0
+	switch pl.Strategy {
+	case PoolRoundRobin:
+		start = pl.next % n
+		pl.next = start + 1
+	case PoolHashIdent:
+		h := fnv.New32a()
+		_, _ = h.Write([]byte(pl.Ident))
+		start = int(h.Sum32() % uint32(n))
+	}
+
+	var available, skipped []int
+	now := timeNow()
+	for i := 0; i < n; i++ {
+		idx := (start + i) % n
+		h := pl.health[idx]
+		if h.Healthy || now.Sub(h.LastCheck) >= probeInterval {
+			available = append(available, idx)
+		} else {
+			skipped = append(skipped, idx)
+		}
+	}
+	pl.x.Unlock()
+
+	var err error
+	for _, idx := range append(available, skipped...) {
+		// Not holding the lock here because the actual Syslog call may
+		// take some time.
+		e := pl.Sysloggers[idx].Syslog(p, msg)
+
+		pl.x.Lock()
+		h := &pl.health[idx]
+		h.LastCheck = timeNow()
+		if e == nil {
+			h.Healthy = true
+			h.Failures = 0
+			h.LastError = nil
+		} else {
+			h.Healthy = false
+			h.Failures++
+			h.LastError = e
+		}
+		pl.x.Unlock()
+
+		if e == nil {
+			return nil
+		}
+
+		err = e
+	}
+
+	return err
+}
+
+// Health gives the current health of each destination, in the same order as
+// the Sysloggers.
+func (pl *Pool) Health() []PoolHealth {
+	pl.x.Lock()
+	defer pl.x.Unlock()
+
+	pl.init()
+
+	res := make([]PoolHealth, len(pl.health))
+	copy(res, pl.health)
+	return res
+}
+
+func (pl *Pool) init() {
+	for len(pl.health) < len(pl.Sysloggers) {
+		pl.health = append(pl.health, PoolHealth{Healthy: true})
+	}
+}
+
+// DialPool creates a new Pool of NativeSyslogs connected to each of the given
+// remote addresses using log/syslog.Dial. Each connection is made the first
+// time it is needed, and a destination which can't be reached is treated as
+// unhealthy.
+func DialPool(
+	network string,
+	raddrs []string,
+	strategy PoolStrategy,
+	f pri.Priority,
+	ident string,
+) (*Pool, error) {
+	if e := f.ValidFacility(); e != nil {
+		return nil, e
+	}
+
+	if len(raddrs) == 0 {
+		return nil, errors.New(
+			"A syslogger.Pool must have at least one destination," +
+				" but no remote addresses were given to" +
+				" syslogger.DialPool(...).",
+		)
+	}
+
+	pl := &Pool{
+		Strategy: strategy,
+		Ident:    ident,
+	}
+
+	for _, raddr := range raddrs {
+		raddr := raddr
+		d, e := NewDelay(func() (Syslogger, error) {
+			return poolDialNativeSyslog(network, raddr, f, ident)
+		})
+		if e != nil {
+			return nil, e
+		}
+
+		pl.Sysloggers = append(pl.Sysloggers, d)
+	}
+
+	return pl, nil
+}
+
+// Flush flushes the other syslogger.Sysloggers.
+func (pl *Pool) Flush() error {
+	return flushAll(pl.Sysloggers...)
+}
+
+// Close closes the other syslogger.Sysloggers, only the first time it is
+// called.
+func (pl *Pool) Close() error {
+	return pl.co.close(func() error {
+		return closeAll(pl.Sysloggers...)
+	})
+}
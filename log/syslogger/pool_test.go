@@ -0,0 +1,242 @@
+package syslogger
+
+import (
+	"log/syslog"
+	"testing"
+	"time"
+
+	"github.com/proidiot/gone/errors"
+	"github.com/proidiot/gone/log/pri"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPoolSyslog(t *testing.T) {
+	origTimeNow := timeNow
+	defer func() {
+		timeNow = origTimeNow
+	}()
+
+	type step struct {
+		advance       time.Duration
+		fail          []bool
+		expectedError bool
+		expectedDest  int
+	}
+
+	type testCase struct {
+		inputDestinations int
+		inputStrategy     PoolStrategy
+		inputIdent        string
+		steps             []step
+		expectedHealthy   []bool
+	}
+
+	tests := map[string]testCase{
+		"nil values": {
+			steps: []step{
+				{expectedError: true, expectedDest: -1},
+			},
+			expectedHealthy: []bool{},
+		},
+		"failover": {
+			inputDestinations: 3,
+			steps: []step{
+				{expectedDest: 0},
+				{fail: []bool{true}, expectedDest: 1},
+				{expectedDest: 1},
+				{
+					advance:      DefaultPoolProbeInterval,
+					expectedDest: 0,
+				},
+			},
+			expectedHealthy: []bool{true, true, true},
+		},
+		"failover still down": {
+			inputDestinations: 2,
+			steps: []step{
+				{fail: []bool{true}, expectedDest: 1},
+				{
+					advance:      DefaultPoolProbeInterval,
+					fail:         []bool{true},
+					expectedDest: 1,
+				},
+				{expectedDest: 1},
+			},
+			expectedHealthy: []bool{false, true},
+		},
+		"all down": {
+			inputDestinations: 2,
+			steps: []step{
+				{
+					fail:          []bool{true, true},
+					expectedError: true,
+					expectedDest:  -1,
+				},
+				{fail: []bool{true}, expectedDest: 1},
+			},
+			expectedHealthy: []bool{false, true},
+		},
+		"round robin": {
+			inputDestinations: 3,
+			inputStrategy:     PoolRoundRobin,
+			steps: []step{
+				{expectedDest: 0},
+				{expectedDest: 1},
+				{expectedDest: 2},
+				{expectedDest: 0},
+				{fail: []bool{false, true}, expectedDest: 2},
+				{expectedDest: 2},
+				{expectedDest: 0},
+			},
+			expectedHealthy: []bool{true, false, true},
+		},
+		"hash ident": {
+			inputDestinations: 3,
+			inputStrategy:     PoolHashIdent,
+			inputIdent:        "a",
+			steps: []step{
+				// The 32-bit FNV-1a hash of "a" is 0xe40c292c.
+				{expectedDest: 0xe40c292c % 3},
+				{expectedDest: 0xe40c292c % 3},
+				{
+					fail:         []bool{false, true},
+					expectedDest: 2,
+				},
+				{expectedDest: 2},
+			},
+			expectedHealthy: []bool{true, false, true},
+		},
+	}
+
+	for explanation, test := range tests {
+		now := time.Date(2001, 2, 3, 4, 5, 6, 0, time.UTC)
+		timeNow = func() time.Time {
+			return now
+		}
+
+		sws := []*switchSyslogger{}
+		pl := &Pool{
+			Strategy: test.inputStrategy,
+			Ident:    test.inputIdent,
+		}
+		for i := 0; i < test.inputDestinations; i++ {
+			sw := &switchSyslogger{}
+			sws = append(sws, sw)
+			pl.Sysloggers = append(pl.Sysloggers, sw)
+		}
+
+		for i, s := range test.steps {
+			now = now.Add(s.advance)
+
+			counts := []int{}
+			for j, sw := range sws {
+				sw.Fail = j < len(s.fail) && s.fail[j]
+				counts = append(counts, len(sw.M))
+			}
+
+			actualError := pl.Syslog(pri.Info, "pool message")
+
+			if s.expectedError {
+				assert.Errorf(
+					t,
+					actualError,
+					"Pool test expects an error at step %d"+
+						" for: %s",
+					i,
+					explanation,
+				)
+			} else {
+				assert.NoError(
+					t,
+					actualError,
+					"Pool test expects no error at step %d"+
+						" for: %s",
+					i,
+					explanation,
+				)
+			}
+
+			actualDest := -1
+			for j, sw := range sws {
+				if len(sw.M) != counts[j] {
+					actualDest = j
+				}
+			}
+			assert.Equal(
+				t,
+				s.expectedDest,
+				actualDest,
+				"Pool test expects a specific destination at"+
+					" step %d for: %s",
+				i,
+				explanation,
+			)
+		}
+
+		actualHealthy := []bool{}
+		for _, h := range pl.Health() {
+			actualHealthy = append(actualHealthy, h.Healthy)
+			if !h.Healthy {
+				assert.Error(t, h.LastError)
+				assert.NotZero(t, h.Failures)
+			}
+		}
+		assert.Equal(
+			t,
+			test.expectedHealthy,
+			actualHealthy,
+			"Pool test expects specific health for: %s",
+			explanation,
+		)
+	}
+}
+
+func TestPoolDialPool(t *testing.T) {
+	origDialNativeSyslog := poolDialNativeSyslog
+	defer func() {
+		poolDialNativeSyslog = origDialNativeSyslog
+	}()
+
+	dialed := []string{}
+	poolDialNativeSyslog = func(
+		network string,
+		raddr string,
+		f pri.Priority,
+		ident string,
+	) (*NativeSyslog, error) {
+		dialed = append(dialed, raddr)
+		if raddr == "down:514" {
+			return nil, errors.New("Artificial error for Dial")
+		}
+		return &NativeSyslog{w: &syslog.Writer{}, f: f}, nil
+	}
+
+	_, e := DialPool("udp", nil, PoolFailover, pri.User, "dial")
+	assert.Error(t, e, "DialPool expects an error without addresses.")
+
+	_, e = DialPool("udp", []string{"up:514"}, PoolFailover, pri.Err, "")
+	assert.Error(t, e, "DialPool expects an error for a bad facility.")
+
+	pl, e := DialPool(
+		"udp",
+		[]string{"down:514", "up:514"},
+		PoolRoundRobin,
+		pri.Local0,
+		"dial",
+	)
+	require.NoError(t, e, "DialPool expects no error.")
+	assert.Empty(t, dialed, "DialPool expects connections to be delayed.")
+	assert.Equal(t, PoolRoundRobin, pl.Strategy)
+	assert.Equal(t, "dial", pl.Ident)
+	require.Len(t, pl.Sysloggers, 2)
+
+	_, e = pl.Sysloggers[0].(*Delay).cb()
+	assert.Error(t, e, "DialPool expects the down destination to fail.")
+
+	s, e := pl.Sysloggers[1].(*Delay).cb()
+	assert.NoError(t, e, "DialPool expects the up destination to work.")
+	assert.IsType(t, &NativeSyslog{}, s)
+
+	assert.Equal(t, []string{"down:514", "up:514"}, dialed)
+}
@@ -1,9 +1,12 @@
 package syslogger
 
 import (
+	"fmt"
 	"io"
 	"os"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/proidiot/gone/errors"
 	"github.com/proidiot/gone/log/mask"
@@ -12,14 +15,40 @@ import (
 )
 
 // Posixish is a syslogger.Syslogger that behaves much like the syslog system
-// specified in POSIX.
+// specified in POSIX. Its log mask.Mask (as well as its mask.Facility and
+// mask.Selector) is consulted on each call to Syslog, so it is kept across
+// calls to Openlog and Closelog.
+//
+// The settings of a Posixish are never changed in place. Instead, each change
+// stores a new posixishState, so that Syslog never has to wait on a lock once
+// the syslog connection has been created.
 type Posixish struct {
-	i string
-	o opt.Option
-	f pri.Priority
-	l Syslogger
-	c []io.Closer
-	x sync.RWMutex
+	p   atomic.Pointer[posixishState]
+	c   []io.Closer
+	t   *time.Timer
+	gen int
+	x   sync.Mutex
+}
+
+// posixishState is the settings of a Posixish along with the
+// syslogger.Syslogger built from them, which must not be changed once stored.
+type posixishState struct {
+	i    string
+	o    opt.Option
+	f    pri.Priority
+	l    Syslogger
+	m    mask.Mask
+	base mask.Mask
+	fm   mask.Facility
+	s    mask.Selector
+}
+
+// posixishDefault is the posixishState of a Posixish which hasn't been changed.
+var posixishDefault = &posixishState{
+	m:    mask.Mask(0xFF),
+	base: mask.Mask(0xFF),
+	fm:   mask.AllFacilities,
+	s:    mask.SelectAll(),
 }
 
 var posixishNewNativeSyslog = NewNativeSyslog
@@ -30,20 +59,82 @@ var posixishOsStderr = os.Stderr
 // Syslog logs a message. How this message is routed depends on what settings
 // were given to Openlog (and potentially a log mask).
 func (px *Posixish) Syslog(p pri.Priority, msg interface{}) error {
-	px.x.RLock()
-	// The read unlock isn't being deferred here because t.Syslog could
-	// take some time in the best case, but it might even need to acquire a
-	// write lock if the syslog connection creation is being deferred.
-	t := px.l
-	px.x.RUnlock()
+	st := px.state()
+	if st.masked(p) {
+		return nil
+	}
+
+	// The message is turned into text up front since that is all the
+	// pipeline can log, and a message which can't be logged anywhere must
+	// not look like a failure of syslogd to its CircuitBreaker.
+	s, ok := messageText(msg)
+	if !ok {
+		return errors.New(
+			"The posixish.Syslogger expects the message argument" +
+				" to have the type string, []byte," +
+				" fmt.Stringer, or error, but the given" +
+				" message argument does not have one of" +
+				" these types.",
+		)
+	}
+
+	if (st.o & opt.Caller) != 0 {
+		// The caller must be found before the message can be handed
+		// to another goroutine by NoWait.
+		s = callerPrefix() + s
+	}
+
+	return px.deliver(st, p, s)
+}
+
+func (px *Posixish) state() *posixishState {
+	if st := px.p.Load(); st != nil {
+		return st
+	}
+
+	return posixishDefault
+}
+
+// update stores a copy of the current posixishState after it has been changed
+// by the given function. It must be called while holding the lock.
+func (px *Posixish) update(f func(*posixishState) error) error {
+	st := *px.state()
+	if e := f(&st); e != nil {
+		return e
+	}
 
+	px.p.Store(&st)
+	return nil
+}
+
+func (st *posixishState) masked(p pri.Priority) bool {
+	if st.m.Masked(p) {
+		return true
+	}
+
+	f := effectiveFacility(p, st.f)
+	return st.fm.Masked(f) || !st.s.Match(f|p.Severity())
+}
+
+func (px *Posixish) deliver(
+	st *posixishState,
+	p pri.Priority,
+	msg interface{},
+) error {
+	t := st.l
 	if t == nil {
 		px.x.Lock()
 
-		px.f = pri.User
+		e := px.update(func(st *posixishState) error {
+			if st.l != nil {
+				// Another call got here first.
+				return nil
+			}
 
-		e := px.prepareDelay()
-		t = px.l
+			st.f = pri.User
+			return px.prepareDelay(st)
+		})
+		t = px.state().l
 
 		px.x.Unlock()
 
@@ -77,21 +168,23 @@ func (px *Posixish) Openlog(
 	px.x.Lock()
 	defer px.x.Unlock()
 
-	px.i = ident
-	px.o = options
-	px.f = facility
+	return px.update(func(st *posixishState) error {
+		st.i = ident
+		st.o = options
+		st.f = facility
 
-	if (px.o & opt.NDelay) != 0 {
-		l, e := px.openlog()
-		if e != nil {
-			return e
-		}
+		if (st.o & opt.NDelay) != 0 {
+			l, e := px.openlog(st)
+			if e != nil {
+				return e
+			}
 
-		px.l = l
-		return nil
-	}
+			st.l = l
+			return nil
+		}
 
-	return px.prepareDelay()
+		return px.prepareDelay(st)
+	})
 }
 
 // Close closes a Posixish, which has basically no effect other than to reset
@@ -101,37 +194,203 @@ func (px *Posixish) Close() error {
 }
 
 // Closelog closes a Posixish, which has basically no effect other than to reset
-// all the file descriptors.
+// all the file descriptors (once any messages which are still being logged
+// have been flushed).
 func (px *Posixish) Closelog() error {
+	// Not holding the lock while flushing, since the messages being
+	// flushed may need to acquire it if the syslog connection creation is
+	// being deferred.
+	err := px.Flush()
+
 	px.x.Lock()
 	defer px.x.Unlock()
-	return px.closelog()
+
+	if e := px.closeConns(); err == nil {
+		err = e
+	}
+
+	_ = px.update(func(st *posixishState) error {
+		st.l = nil
+		return nil
+	})
+
+	return err
 }
 
-// SetLogMask sets the Posixish's log mask.Mask.
-func (px *Posixish) SetLogMask(m mask.Mask) error {
+// Flush flushes any messages which are still being logged.
+func (px *Posixish) Flush() error {
+	return flushAll(px.state().l)
+}
+
+// SetLogMask replaces the Posixish's log mask.Mask and gives the previous one,
+// much like setlogmask in POSIX. Also like setlogmask, a zero mask.Mask leaves
+// the log mask.Mask unchanged, so it can be used to query it. Any temporary
+// mask.Mask given to SetLogMaskFor is ended.
+func (px *Posixish) SetLogMask(m mask.Mask) mask.Mask {
+	if m == 0x00 {
+		return px.LogMask()
+	}
+
 	px.x.Lock()
 	defer px.x.Unlock()
-	if px.l == nil {
-		px.f = pri.User
 
-		if e := px.prepareDelay(); e != nil {
-			return e
-		}
+	px.stopTimer()
+
+	prev := px.state().m
+	_ = px.update(func(st *posixishState) error {
+		st.m = m
+		st.base = m
+		return nil
+	})
+
+	return prev
+}
+
+// SetLogMaskFor replaces the Posixish's log mask.Mask temporarily, so that it
+// reverts after the given length of time. A notice is logged when the
+// temporary mask.Mask is set and when it reverts, regardless of any mask.
+func (px *Posixish) SetLogMaskFor(m mask.Mask, d time.Duration) error {
+	px.x.Lock()
+
+	gen := px.stopTimer()
+	px.t = timeAfterFunc(d, func() {
+		px.revertLogMask(gen)
+	})
+
+	prev := px.state().m
+	_ = px.update(func(st *posixishState) error {
+		st.m = m
+		return nil
+	})
+
+	px.x.Unlock()
+
+	// Not holding the lock here because the notice may need to acquire it
+	// if the syslog connection creation is being deferred. Errors from
+	// logging the notice are ignored, since the mask applies either way.
+	_ = px.deliver(
+		px.state(),
+		pri.Notice,
+		fmt.Sprintf(
+			"Log mask temporarily changed from %s to %s for %s.",
+			prev,
+			m,
+			d,
+		),
+	)
+
+	return nil
+}
+
+// stopTimer stops the timer for any temporary mask.Mask and gives the
+// generation of the next one, so that a timer which fired before it could be
+// stopped knows not to revert the mask.Mask. It must be called while holding
+// the lock.
+func (px *Posixish) stopTimer() int {
+	if px.t != nil {
+		px.t.Stop()
+		px.t = nil
 	}
-	px.l = &SeverityMask{
-		Syslogger: px.l,
-		Mask:      m,
+
+	px.gen++
+	return px.gen
+}
+
+func (px *Posixish) revertLogMask(gen int) {
+	px.x.Lock()
+	if px.gen != gen {
+		px.x.Unlock()
+		return
 	}
-	return nil
+
+	px.t = nil
+	prev := px.state().m
+	_ = px.update(func(st *posixishState) error {
+		st.m = st.base
+		return nil
+	})
+	m := px.state().m
+
+	px.x.Unlock()
+
+	_ = px.deliver(
+		px.state(),
+		pri.Notice,
+		fmt.Sprintf("Log mask reverted from %s to %s.", prev, m),
+	)
+}
+
+// SetFacilityMask replaces the Posixish's log mask.Facility, so that messages
+// from the masked facilities are discarded, and gives the previous one.
+func (px *Posixish) SetFacilityMask(m mask.Facility) mask.Facility {
+	px.x.Lock()
+	defer px.x.Unlock()
+
+	prev := px.state().fm
+	_ = px.update(func(st *posixishState) error {
+		st.fm = m
+		return nil
+	})
+
+	return prev
+}
+
+// SetSelector replaces the Posixish's log mask.Selector, so that only messages
+// it selects are logged, and gives the previous one.
+func (px *Posixish) SetSelector(s mask.Selector) mask.Selector {
+	px.x.Lock()
+	defer px.x.Unlock()
+
+	prev := px.state().s
+	_ = px.update(func(st *posixishState) error {
+		st.s = s
+		return nil
+	})
+
+	return prev
+}
+
+// Ident gives the identity given to Openlog.
+func (px *Posixish) Ident() string {
+	return px.state().i
+}
+
+// Options gives the opt.Option given to Openlog.
+func (px *Posixish) Options() opt.Option {
+	return px.state().o
+}
+
+// Facility gives the default log facility given to Openlog (which is pri.User
+// if the Posixish was used without calling Openlog).
+func (px *Posixish) Facility() pri.Priority {
+	return px.state().f
 }
 
-func (px *Posixish) prepareDelay() error {
+// LogMask gives the log mask.Mask of the Posixish, which is the temporary
+// mask.Mask given to SetLogMaskFor if one is active.
+func (px *Posixish) LogMask() mask.Mask {
+	return px.state().m
+}
+
+// FacilityMask gives the log mask.Facility of the Posixish.
+func (px *Posixish) FacilityMask() mask.Facility {
+	return px.state().fm
+}
+
+// Selector gives the log mask.Selector of the Posixish.
+func (px *Posixish) Selector() mask.Selector {
+	return px.state().s
+}
+
+// prepareDelay gives the posixishState a syslogger.Syslogger which creates the
+// syslog connection (based on the posixishState) the first time it is used.
+func (px *Posixish) prepareDelay(st *posixishState) error {
+	opened := *st
 	l, e := posixishNewDelay(
 		func() (Syslogger, error) {
 			px.x.Lock()
 			defer px.x.Unlock()
-			return px.openlog()
+			return px.openlog(&opened)
 		},
 	)
 
@@ -139,36 +398,33 @@ func (px *Posixish) prepareDelay() error {
 		return e
 	}
 
-	px.l = l
+	st.l = l
 	return nil
 }
 
-func (px *Posixish) openlog() (Syslogger, error) {
-	if e := px.closelog(); e != nil {
+func (px *Posixish) openlog(st *posixishState) (Syslogger, error) {
+	// Only the old connections are closed here, since a deferred open must
+	// leave the rest of the Posixish alone.
+	if e := px.closeConns(); e != nil {
 		return nil, e
 	}
 
 	var l Syslogger
 
-	if n, e := posixishNewNativeSyslog(px.f, px.i); e == nil {
+	if n, e := posixishNewNativeSyslog(st.f, st.i); e == nil {
 		px.c = append(px.c, n)
 		l = n
 	}
 
-	if (px.o & opt.Cons) != 0 {
+	if (st.o & opt.Cons) != 0 {
 		if f, e := posixishOsOpen("/dev/console"); e == nil {
 			px.c = append(px.c, f)
 
-			c := &Rfc3164{
-				Syslogger: &Writer{f},
-				Facility:  px.f,
-				Ident:     px.i,
-				Pid:       (px.o & opt.Pid) != 0,
-			}
+			c := st.console(st.rfc3164(&Writer{Writer: f}))
 
 			if l != nil {
 				l = &Fallthrough{
-					Default:     l,
+					Default:     nativeBreaker(l),
 					Fallthrough: c,
 				}
 			} else {
@@ -177,7 +433,7 @@ func (px *Posixish) openlog() (Syslogger, error) {
 		}
 	}
 
-	if (px.o&opt.Perror) == 0 && (px.o&opt.NoFallback) != 0 {
+	if (st.o&opt.Perror) == 0 && (st.o&opt.NoFallback) != 0 {
 		if l == nil {
 			return nil, errors.New(
 				"The posixish.Syslogger was unable to" +
@@ -192,18 +448,23 @@ func (px *Posixish) openlog() (Syslogger, error) {
 			)
 		}
 	} else {
-		es := &Newliner{
-			Syslogger: &Rfc3164{
-				Syslogger: &Writer{posixishOsStderr},
-				Facility:  px.f,
-				Ident:     px.i,
-				Pid:       (px.o & opt.Pid) != 0,
-			},
+		var es Syslogger
+		if osIsTerminal(posixishOsStderr) {
+			es = st.console(&Console{
+				File:  posixishOsStderr,
+				Ident: st.i,
+				Pid:   (st.o & opt.Pid) != 0,
+			})
+		} else {
+			w := &Newliner{
+				Syslogger: &Writer{Writer: posixishOsStderr},
+			}
+			es = st.console(st.rfc3164(w))
 		}
 
 		if l == nil {
 			l = es
-		} else if (px.o & opt.Perror) != 0 {
+		} else if (st.o & opt.Perror) != 0 {
 			l = &Multi{
 				Sysloggers: []Syslogger{
 					l,
@@ -213,20 +474,55 @@ func (px *Posixish) openlog() (Syslogger, error) {
 			}
 		} else {
 			l = &Fallthrough{
-				Default:     l,
+				Default:     nativeBreaker(l),
 				Fallthrough: es,
 			}
 		}
 	}
 
-	if (px.o & opt.NoWait) != 0 {
-		l = &NoWait{l}
+	if (st.o & opt.NoWait) != 0 {
+		l = &NoWait{Syslogger: l}
 	}
 
 	return l, nil
 }
 
-func (px *Posixish) closelog() error {
+// nativeBreaker wraps the connection to syslogd in a CircuitBreaker, so that a
+// dead syslogd doesn't delay every message on its way to the fallback. A
+// message from a facility other than the one the connection was opened with
+// still goes to the fallback, but isn't counted as a failure of syslogd.
+func nativeBreaker(s Syslogger) Syslogger {
+	return &CircuitBreaker{
+		Syslogger: s,
+		Failure: func(e error) bool {
+			_, ok := e.(*nativeFacilityError)
+			return !ok
+		},
+	}
+}
+
+// console wraps the formatting syslogger.Syslogger used to write messages to a
+// console (either the system console or stderr), where each record must be a
+// single line which is safe to display.
+func (st *posixishState) console(s Syslogger) Syslogger {
+	return &Multiline{
+		Syslogger: &Sanitizer{
+			Syslogger: s,
+		},
+		Policy: MultilineSplit,
+	}
+}
+
+func (st *posixishState) rfc3164(s Syslogger) Syslogger {
+	return &Rfc3164{
+		Syslogger: s,
+		Facility:  st.f,
+		Ident:     st.i,
+		Pid:       (st.o & opt.Pid) != 0,
+	}
+}
+
+func (px *Posixish) closeConns() error {
 	var err error
 
 	for _, c := range px.c {
@@ -236,7 +532,6 @@ func (px *Posixish) closelog() error {
 		}
 	}
 
-	px.l = nil
-
+	px.c = nil
 	return err
 }
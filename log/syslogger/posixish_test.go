@@ -2,9 +2,13 @@ package syslogger
 
 import (
 	"io"
+	"io/ioutil"
 	"log/syslog"
+	"net"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/proidiot/gone/errors"
 	"github.com/proidiot/gone/log/mask"
@@ -112,7 +116,7 @@ func TestPosixishOpenlog(t *testing.T) {
 			inputOptions:              opt.NDelay,
 			causeNewNativeSyslogError: true,
 			expectedError:             false,
-			expectedSysloggerType:     &Newliner{},
+			expectedSysloggerType:     &Multiline{},
 			expectedClosers:           []io.Closer{},
 		},
 		"no delay, cons": {
@@ -223,7 +227,7 @@ func TestPosixishOpenlog(t *testing.T) {
 			)
 		}
 
-		actualSyslogger := p.l
+		actualSyslogger := p.state().l
 		assert.IsType(
 			t,
 			test.expectedSysloggerType,
@@ -348,7 +352,9 @@ func TestPosixishSyslog(t *testing.T) {
 			)
 		}
 		if test.causeOpenlogError {
-			p.o |= opt.NoFallback
+			st := *p.state()
+			st.o |= opt.NoFallback
+			p.p.Store(&st)
 		}
 		if test.causeNewDelayError {
 			posixishNewDelay = errorNewDelay
@@ -377,45 +383,75 @@ func TestPosixishSyslog(t *testing.T) {
 }
 
 func TestPosixishSetLogMask(t *testing.T) {
-	origNewDelay := posixishNewDelay
-	defer func() {
-		posixishNewDelay = origNewDelay
-	}()
-	errorNewDelay := func(func() (Syslogger, error)) (*Delay, error) {
-		return nil, errors.New("Artificial error for NewDelay")
-	}
-
 	type testCase struct {
-		inputMask               mask.Mask
-		causeBlankOpenlog       bool
-		causeNewDelayError      bool
-		expectedError           bool
-		expectedMaskedSyslogger Syslogger
+		inputMasks        []mask.Mask
+		causeLaterOpenlog bool
+		inputPri          pri.Priority
+		expectedPrevious  []mask.Mask
+		expectedMask      mask.Mask
+		expectedCall      bool
 	}
 
 	tests := map[string]testCase{
 		"nil values": {
-			inputMask:               mask.Mask(0x0),
-			expectedError:           false,
-			expectedMaskedSyslogger: &Delay{},
+			inputMasks:       []mask.Mask{mask.Mask(0x0)},
+			inputPri:         pri.Debug,
+			expectedPrevious: []mask.Mask{mask.Mask(0xFF)},
+			expectedMask:     mask.Mask(0xFF),
+			expectedCall:     true,
 		},
-		"follow-on log mask": {
-			inputMask:               mask.Warning,
-			causeBlankOpenlog:       true,
-			expectedError:           false,
-			expectedMaskedSyslogger: &Delay{},
+		"masked": {
+			inputMasks:       []mask.Mask{mask.UpTo(pri.Err)},
+			inputPri:         pri.Warning,
+			expectedPrevious: []mask.Mask{mask.Mask(0xFF)},
+			expectedMask:     mask.UpTo(pri.Err),
+			expectedCall:     false,
 		},
-		"delay error": {
-			inputMask:          mask.Notice,
-			causeNewDelayError: true,
-			expectedError:      true,
+		"widened": {
+			inputMasks: []mask.Mask{
+				mask.UpTo(pri.Err),
+				mask.UpTo(pri.Debug),
+			},
+			inputPri: pri.Warning,
+			expectedPrevious: []mask.Mask{
+				mask.Mask(0xFF),
+				mask.UpTo(pri.Err),
+			},
+			expectedMask: mask.UpTo(pri.Debug),
+			expectedCall: true,
+		},
+		"zero mask queries": {
+			inputMasks: []mask.Mask{
+				mask.UpTo(pri.Err),
+				mask.Mask(0x0),
+			},
+			inputPri: pri.Err,
+			expectedPrevious: []mask.Mask{
+				mask.Mask(0xFF),
+				mask.UpTo(pri.Err),
+			},
+			expectedMask: mask.UpTo(pri.Err),
+			expectedCall: true,
+		},
+		"kept by openlog": {
+			inputMasks:        []mask.Mask{mask.UpTo(pri.Err)},
+			causeLaterOpenlog: true,
+			inputPri:          pri.Warning,
+			expectedPrevious:  []mask.Mask{mask.Mask(0xFF)},
+			expectedMask:      mask.UpTo(pri.Err),
+			expectedCall:      false,
 		},
 	}
 
 	for explanation, test := range tests {
 		p := new(Posixish)
 
-		if test.causeBlankOpenlog {
+		var actualPrevious []mask.Mask
+		for _, m := range test.inputMasks {
+			actualPrevious = append(actualPrevious, p.SetLogMask(m))
+		}
+
+		if test.causeLaterOpenlog {
 			openError := p.Openlog(
 				"",
 				opt.Option(0x0),
@@ -429,57 +465,375 @@ func TestPosixishSetLogMask(t *testing.T) {
 				explanation,
 			)
 		}
-		if test.causeNewDelayError {
-			posixishNewDelay = errorNewDelay
-		} else {
-			posixishNewDelay = origNewDelay
-		}
 
-		actualError := p.SetLogMask(test.inputMask)
+		assert.Equal(
+			t,
+			test.expectedPrevious,
+			actualPrevious,
+			"Posixish SetLogMask test expects different previous"+
+				" masks for: %s",
+			explanation,
+		)
 
-		if test.expectedError {
-			assert.Errorf(
-				t,
-				actualError,
-				"Posixish SetLogMask test expects an error"+
-					" for: %s",
-				explanation,
+		assert.Equal(
+			t,
+			test.expectedMask,
+			p.LogMask(),
+			"Posixish SetLogMask test expects a different mask"+
+				" for: %s",
+			explanation,
+		)
+
+		ra := &recordAllSyslogger{}
+		st := *p.state()
+		st.l = ra
+		p.p.Store(&st)
+
+		e := p.Syslog(test.inputPri, "message")
+		assert.NoError(
+			t,
+			e,
+			"Posixish SetLogMask test expects no error for: %s",
+			explanation,
+		)
+
+		assert.Equal(
+			t,
+			test.expectedCall,
+			len(ra.M) > 0,
+			"Posixish SetLogMask test call check failure for: %s",
+			explanation,
+		)
+	}
+}
+
+func TestPosixishSetFacilityMask(t *testing.T) {
+	type testCase struct {
+		inputMasks        []mask.Facility
+		causeLaterOpenlog bool
+		inputFacility     pri.Priority
+		inputPri          pri.Priority
+		expectedPrevious  []mask.Facility
+		expectedMask      mask.Facility
+		expectedCall      bool
+	}
+
+	tests := map[string]testCase{
+		"nil values": {
+			inputMasks:       []mask.Facility{mask.Facility(0x0)},
+			inputPri:         pri.Err,
+			expectedPrevious: []mask.Facility{mask.AllFacilities},
+			expectedMask:     mask.Facility(0x0),
+			expectedCall:     false,
+		},
+		"default facility": {
+			inputMasks: []mask.Facility{
+				mask.FacilityOf(pri.User),
+			},
+			inputPri:         pri.Err,
+			expectedPrevious: []mask.Facility{mask.AllFacilities},
+			expectedMask:     mask.FacilityOf(pri.User),
+			expectedCall:     true,
+		},
+		"explicit facility": {
+			inputMasks: []mask.Facility{
+				mask.FacilityOf(pri.User),
+			},
+			inputPri:         pri.Auth | pri.Err,
+			expectedPrevious: []mask.Facility{mask.AllFacilities},
+			expectedMask:     mask.FacilityOf(pri.User),
+			expectedCall:     false,
+		},
+		"replaced": {
+			inputMasks: []mask.Facility{
+				mask.FacilityOf(pri.User),
+				mask.FacilityOf(pri.Auth),
+			},
+			inputPri: pri.Auth | pri.Err,
+			expectedPrevious: []mask.Facility{
+				mask.AllFacilities,
+				mask.FacilityOf(pri.User),
+			},
+			expectedMask: mask.FacilityOf(pri.Auth),
+			expectedCall: true,
+		},
+		"kept by openlog": {
+			inputMasks: []mask.Facility{
+				mask.FacilityOf(pri.Mail),
+			},
+			causeLaterOpenlog: true,
+			inputFacility:     pri.Mail,
+			inputPri:          pri.Err,
+			expectedPrevious:  []mask.Facility{mask.AllFacilities},
+			expectedMask:      mask.FacilityOf(pri.Mail),
+			expectedCall:      true,
+		},
+	}
+
+	for explanation, test := range tests {
+		p := new(Posixish)
+
+		var actualPrevious []mask.Facility
+		for _, m := range test.inputMasks {
+			actualPrevious = append(
+				actualPrevious,
+				p.SetFacilityMask(m),
+			)
+		}
+
+		if test.causeLaterOpenlog {
+			openError := p.Openlog(
+				"",
+				opt.Option(0x0),
+				test.inputFacility,
 			)
-		} else {
 			assert.NoError(
 				t,
-				actualError,
-				"Posixish SetLogMask test expects no error"+
-					" for: %s",
+				openError,
+				"Posixish SetFacilityMask test expects no"+
+					" error during Openlog for: %s",
 				explanation,
 			)
 		}
 
-		if actualError == nil {
-			actualSyslogger := p.l
-			assert.IsType(
+		assert.Equal(
+			t,
+			test.expectedPrevious,
+			actualPrevious,
+			"Posixish SetFacilityMask test expects different"+
+				" previous masks for: %s",
+			explanation,
+		)
+
+		assert.Equal(
+			t,
+			test.expectedMask,
+			p.FacilityMask(),
+			"Posixish SetFacilityMask test expects a different"+
+				" mask for: %s",
+			explanation,
+		)
+
+		ra := &recordAllSyslogger{}
+		st := *p.state()
+		st.l = ra
+		p.p.Store(&st)
+
+		e := p.Syslog(test.inputPri, "message")
+		assert.NoError(
+			t,
+			e,
+			"Posixish SetFacilityMask test expects no error"+
+				" for: %s",
+			explanation,
+		)
+
+		assert.Equal(
+			t,
+			test.expectedCall,
+			len(ra.M) > 0,
+			"Posixish SetFacilityMask test call check failure"+
+				" for: %s",
+			explanation,
+		)
+	}
+}
+
+func TestPosixishSetSelector(t *testing.T) {
+	authOnly, e := mask.ParseSelector("auth.*")
+	require.NoError(t, e)
+	errUser, e := mask.ParseSelector("user.err")
+	require.NoError(t, e)
+
+	type testCase struct {
+		inputSelectors    []mask.Selector
+		causeLaterOpenlog bool
+		inputPri          pri.Priority
+		expectedPrevious  []mask.Selector
+		expectedSelector  mask.Selector
+		expectedCall      bool
+	}
+
+	tests := map[string]testCase{
+		"nil values": {
+			inputSelectors:   []mask.Selector{{}},
+			inputPri:         pri.Emerg,
+			expectedPrevious: []mask.Selector{mask.SelectAll()},
+			expectedSelector: mask.Selector{},
+			expectedCall:     false,
+		},
+		"selected": {
+			inputSelectors:   []mask.Selector{errUser},
+			inputPri:         pri.Crit,
+			expectedPrevious: []mask.Selector{mask.SelectAll()},
+			expectedSelector: errUser,
+			expectedCall:     true,
+		},
+		"replaced": {
+			inputSelectors: []mask.Selector{
+				errUser,
+				authOnly,
+			},
+			inputPri: pri.Crit,
+			expectedPrevious: []mask.Selector{
+				mask.SelectAll(),
+				errUser,
+			},
+			expectedSelector: authOnly,
+			expectedCall:     false,
+		},
+		"kept by openlog": {
+			inputSelectors:    []mask.Selector{errUser},
+			causeLaterOpenlog: true,
+			inputPri:          pri.Warning,
+			expectedPrevious:  []mask.Selector{mask.SelectAll()},
+			expectedSelector:  errUser,
+			expectedCall:      false,
+		},
+	}
+
+	for explanation, test := range tests {
+		p := new(Posixish)
+
+		var actualPrevious []mask.Selector
+		for _, s := range test.inputSelectors {
+			actualPrevious = append(
+				actualPrevious,
+				p.SetSelector(s),
+			)
+		}
+
+		if test.causeLaterOpenlog {
+			openError := p.Openlog(
+				"",
+				opt.Option(0x0),
+				pri.Priority(0x0),
+			)
+			assert.NoError(
 				t,
-				&SeverityMask{},
-				actualSyslogger,
-				"Posixish SetLogMask test expects a"+
-					" *syslogger.SeverityMask as the"+
-					" Posixish syslogger for: %s",
+				openError,
+				"Posixish SetSelector test expects no"+
+					" error during Openlog for: %s",
 				explanation,
 			)
+		}
+
+		assert.Equal(
+			t,
+			test.expectedPrevious,
+			actualPrevious,
+			"Posixish SetSelector test expects different previous"+
+				" selectors for: %s",
+			explanation,
+		)
+
+		assert.Equal(
+			t,
+			test.expectedSelector,
+			p.Selector(),
+			"Posixish SetSelector test expects a different"+
+				" selector for: %s",
+			explanation,
+		)
+
+		ra := &recordAllSyslogger{}
+		st := *p.state()
+		st.l = ra
+		p.p.Store(&st)
+
+		e := p.Syslog(test.inputPri, "message")
+		assert.NoError(
+			t,
+			e,
+			"Posixish SetSelector test expects no error for: %s",
+			explanation,
+		)
+
+		assert.Equal(
+			t,
+			test.expectedCall,
+			len(ra.M) > 0,
+			"Posixish SetSelector test call check failure for: %s",
+			explanation,
+		)
+	}
+}
+
+func TestPosixishGetters(t *testing.T) {
+	type testCase struct {
+		inputIdent       string
+		inputOptions     opt.Option
+		inputFacility    pri.Priority
+		inputMasks       []mask.Mask
+		expectedFacility pri.Priority
+		expectedMask     mask.Mask
+	}
 
-			if s, ok := actualSyslogger.(*SeverityMask); ok {
-				actualMaskedSyslogger := s.Syslogger
-				assert.IsType(
-					t,
-					test.expectedMaskedSyslogger,
-					actualMaskedSyslogger,
-					"Posixish SetLogMask expects a"+
-						" different masked syslogger"+
-						" for: %s",
-					explanation,
-				)
-			}
+	tests := map[string]testCase{
+		"nil values": {
+			expectedMask: mask.Mask(0xFF),
+		},
+		"single mask": {
+			inputIdent:       "getters",
+			inputOptions:     opt.Pid | opt.Perror,
+			inputFacility:    pri.Local3,
+			inputMasks:       []mask.Mask{mask.UpTo(pri.Info)},
+			expectedFacility: pri.Local3,
+			expectedMask:     mask.UpTo(pri.Info),
+		},
+		"replaced masks": {
+			inputFacility: pri.Mail,
+			inputMasks: []mask.Mask{
+				mask.UpTo(pri.Info),
+				mask.Err | mask.Debug,
+			},
+			expectedFacility: pri.Mail,
+			expectedMask:     mask.Err | mask.Debug,
+		},
+	}
+
+	for explanation, test := range tests {
+		p := new(Posixish)
+
+		e := p.Openlog(
+			test.inputIdent,
+			test.inputOptions,
+			test.inputFacility,
+		)
+		assert.NoError(t, e, "Error during Openlog")
+
+		for _, m := range test.inputMasks {
+			p.SetLogMask(m)
 		}
+
+		assert.Equal(
+			t,
+			test.inputIdent,
+			p.Ident(),
+			"Posixish getters test expects the ident for: %s",
+			explanation,
+		)
+		assert.Equal(
+			t,
+			test.inputOptions,
+			p.Options(),
+			"Posixish getters test expects the options for: %s",
+			explanation,
+		)
+		assert.Equal(
+			t,
+			test.expectedFacility,
+			p.Facility(),
+			"Posixish getters test expects the facility for: %s",
+			explanation,
+		)
+		assert.Equal(
+			t,
+			test.expectedMask,
+			p.LogMask(),
+			"Posixish getters test expects the mask for: %s",
+			explanation,
+		)
 	}
 }
 
@@ -496,3 +850,523 @@ func TestPosixishCloseError(t *testing.T) {
 		"Posixish Close error test expects an error.",
 	)
 }
+
+func TestPosixishCloseReopen(t *testing.T) {
+	origNewNativeSyslog := posixishNewNativeSyslog
+	defer func() {
+		posixishNewNativeSyslog = origNewNativeSyslog
+	}()
+	posixishNewNativeSyslog = func(
+		pri.Priority,
+		string,
+	) (*NativeSyslog, error) {
+		return nil, errors.New("Artificial error for NewNativeSyslog")
+	}
+
+	f, e := ioutil.TempFile("", "posixish")
+	require.NoError(
+		t,
+		e,
+		"Posixish close and reopen test requires a temporary file.",
+	)
+	_ = f.Close()
+	defer func() {
+		_ = os.Remove(f.Name())
+	}()
+
+	origOsOpen := posixishOsOpen
+	defer func() {
+		posixishOsOpen = origOsOpen
+	}()
+	posixishOsOpen = func(string) (*os.File, error) {
+		return os.OpenFile(f.Name(), os.O_WRONLY, 0600)
+	}
+
+	p := new(Posixish)
+	require.NoError(
+		t,
+		p.Openlog("reopen", opt.Cons|opt.NDelay, pri.Local0),
+	)
+
+	assert.NoError(
+		t,
+		p.Closelog(),
+		"Posixish close and reopen test expects no error on the"+
+			" first Closelog.",
+	)
+	assert.NoError(
+		t,
+		p.Closelog(),
+		"Posixish close and reopen test expects no error on a"+
+			" second Closelog.",
+	)
+	assert.NoError(
+		t,
+		p.Openlog("reopen", opt.Cons|opt.NDelay, pri.Local0),
+		"Posixish close and reopen test expects no error on an"+
+			" Openlog after Closelog.",
+	)
+	assert.NoError(
+		t,
+		p.Closelog(),
+		"Posixish close and reopen test expects no error on a"+
+			" Closelog after reopening.",
+	)
+}
+
+func TestPosixishForeignFacility(t *testing.T) {
+	conn, e := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(
+		t,
+		e,
+		"Posixish foreign facility test requires a UDP listener.",
+	)
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	origNewNativeSyslog := posixishNewNativeSyslog
+	defer func() {
+		posixishNewNativeSyslog = origNewNativeSyslog
+	}()
+	posixishNewNativeSyslog = func(
+		f pri.Priority,
+		i string,
+	) (*NativeSyslog, error) {
+		return DialNativeSyslog("udp", conn.LocalAddr().String(), f, i)
+	}
+
+	f, e := ioutil.TempFile("", "posixish")
+	require.NoError(
+		t,
+		e,
+		"Posixish foreign facility test requires a temporary file.",
+	)
+	origOsStderr := posixishOsStderr
+	defer func() {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		posixishOsStderr = origOsStderr
+	}()
+	posixishOsStderr = f
+
+	p := new(Posixish)
+	require.NoError(t, p.Openlog("foreign", opt.NDelay, pri.Local0))
+	defer func() {
+		_ = p.Closelog()
+	}()
+
+	for i := 0; i <= DefaultCircuitBreakerThreshold; i++ {
+		assert.NoError(
+			t,
+			p.Syslog(pri.Auth|pri.Err, "foreign message"),
+			"Posixish foreign facility test expects a foreign"+
+				" facility message to fall back.",
+		)
+	}
+
+	assert.NoError(
+		t,
+		p.Syslog(pri.Local0|pri.Info, "default message"),
+		"Posixish foreign facility test expects no error for a"+
+			" default facility message.",
+	)
+
+	b := make([]byte, 1024)
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+	n, _, e := conn.ReadFrom(b)
+	require.NoError(
+		t,
+		e,
+		"Posixish foreign facility test expects a message to reach"+
+			" syslogd.",
+	)
+	assert.Contains(
+		t,
+		string(b[:n]),
+		"default message",
+		"Posixish foreign facility test expects the default facility"+
+			" message to reach syslogd.",
+	)
+
+	actual, e := ioutil.ReadFile(f.Name())
+	require.NoError(t, e)
+	assert.Equal(
+		t,
+		DefaultCircuitBreakerThreshold+1,
+		strings.Count(string(actual), "foreign message"),
+		"Posixish foreign facility test expects the foreign facility"+
+			" messages to fall back to stderr.",
+	)
+	assert.NotContains(
+		t,
+		string(actual),
+		"default message",
+		"Posixish foreign facility test expects the default facility"+
+			" message not to fall back to stderr.",
+	)
+}
+
+func TestPosixishConsoleOutput(t *testing.T) {
+	origNewNativeSyslog := posixishNewNativeSyslog
+	defer func() {
+		posixishNewNativeSyslog = origNewNativeSyslog
+	}()
+	posixishNewNativeSyslog = func(
+		pri.Priority,
+		string,
+	) (*NativeSyslog, error) {
+		return nil, errors.New("Artificial error for NewNativeSyslog")
+	}
+
+	f, e := ioutil.TempFile("", "posixish")
+	require.NoError(
+		t,
+		e,
+		"Posixish console output test requires a temporary file.",
+	)
+	origOsStderr := posixishOsStderr
+	defer func() {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		posixishOsStderr = origOsStderr
+	}()
+	posixishOsStderr = f
+
+	p := new(Posixish)
+	require.NoError(t, p.Openlog("console", opt.NDelay, pri.Local0))
+
+	assert.NoError(
+		t,
+		p.Syslog(pri.Err, "forged\r<0>\x1b[2J\nstack\n"),
+		"Posixish console output test expects no error.",
+	)
+
+	actual, e := ioutil.ReadFile(f.Name())
+	require.NoError(t, e)
+	assert.Regexp(
+		t,
+		`^<131>[^\n]+ console: forged#015<0>#033\[2J\n`+
+			`<131>[^\n]+ console: \+ stack\n$`,
+		string(actual),
+		"Posixish console output test expects sanitized lines.",
+	)
+}
+
+func TestPosixishCaller(t *testing.T) {
+	origNewNativeSyslog := posixishNewNativeSyslog
+	defer func() {
+		posixishNewNativeSyslog = origNewNativeSyslog
+	}()
+	posixishNewNativeSyslog = func(
+		pri.Priority,
+		string,
+	) (*NativeSyslog, error) {
+		return nil, errors.New("Artificial error for NewNativeSyslog")
+	}
+
+	f, e := ioutil.TempFile("", "posixish")
+	require.NoError(
+		t,
+		e,
+		"Posixish caller test requires a temporary file.",
+	)
+	origOsStderr := posixishOsStderr
+	defer func() {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		posixishOsStderr = origOsStderr
+	}()
+	posixishOsStderr = f
+
+	p := new(Posixish)
+	require.NoError(
+		t,
+		p.Openlog("caller", opt.Caller|opt.NoWait, pri.Local0),
+	)
+
+	assert.NoError(
+		t,
+		p.Syslog(pri.Err, "message"),
+		"Posixish caller test expects no error.",
+	)
+	require.NoError(t, p.Flush())
+
+	actual, e := ioutil.ReadFile(f.Name())
+	require.NoError(t, e)
+	assert.Regexp(
+		t,
+		`^<131>[^\n]+ caller: \[posixish_test\.go:\d+`+
+			` syslogger\.TestPosixishCaller\] message\n$`,
+		string(actual),
+		"Posixish caller test expects the location of the test,"+
+			" even with NoWait.",
+	)
+}
+
+func TestPosixishTerminalOutput(t *testing.T) {
+	origNewNativeSyslog := posixishNewNativeSyslog
+	defer func() {
+		posixishNewNativeSyslog = origNewNativeSyslog
+	}()
+	posixishNewNativeSyslog = func(
+		pri.Priority,
+		string,
+	) (*NativeSyslog, error) {
+		return nil, errors.New("Artificial error for NewNativeSyslog")
+	}
+
+	origIsTerminal := osIsTerminal
+	defer func() {
+		osIsTerminal = origIsTerminal
+	}()
+	osIsTerminal = func(*os.File) bool {
+		return true
+	}
+
+	f, e := ioutil.TempFile("", "posixish")
+	require.NoError(
+		t,
+		e,
+		"Posixish terminal output test requires a temporary file.",
+	)
+	origOsStderr := posixishOsStderr
+	defer func() {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		posixishOsStderr = origOsStderr
+	}()
+	posixishOsStderr = f
+
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("TERM", "xterm")
+
+	p := new(Posixish)
+	require.NoError(t, p.Openlog("terminal", opt.NDelay, pri.Local0))
+
+	assert.NoError(
+		t,
+		p.Syslog(pri.Notice, "first\nsecond"),
+		"Posixish terminal output test expects no error.",
+	)
+
+	actual, e := ioutil.ReadFile(f.Name())
+	require.NoError(t, e)
+	assert.Regexp(
+		t,
+		`^[^\n]+ \x1b\[1mLOG_NOTICE  terminal: first\x1b\[0m\n`+
+			`[^\n]+ \x1b\[1mLOG_NOTICE  terminal: \+ second`+
+			`\x1b\[0m\n$`,
+		string(actual),
+		"Posixish terminal output test expects colored console"+
+			" lines.",
+	)
+}
+
+func TestPosixishSetLogMaskFor(t *testing.T) {
+	origNewNativeSyslog := posixishNewNativeSyslog
+	defer func() {
+		posixishNewNativeSyslog = origNewNativeSyslog
+	}()
+	posixishNewNativeSyslog = func(
+		pri.Priority,
+		string,
+	) (*NativeSyslog, error) {
+		return nil, errors.New("Artificial error for NewNativeSyslog")
+	}
+
+	origTimeAfterFunc := timeAfterFunc
+	defer func() {
+		timeAfterFunc = origTimeAfterFunc
+	}()
+	var expire func()
+	timeAfterFunc = func(d time.Duration, f func()) *time.Timer {
+		expire = f
+		return time.NewTimer(time.Hour)
+	}
+
+	f, e := ioutil.TempFile("", "posixish")
+	require.NoError(
+		t,
+		e,
+		"Posixish SetLogMaskFor test requires a temporary file.",
+	)
+	origOsStderr := posixishOsStderr
+	defer func() {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		posixishOsStderr = origOsStderr
+	}()
+	posixishOsStderr = f
+
+	p := new(Posixish)
+	require.NoError(t, p.Openlog("timed", opt.NDelay, pri.Local0))
+
+	assert.NoError(
+		t,
+		p.SetLogMaskFor(mask.UpTo(pri.Err), time.Minute),
+		"Posixish SetLogMaskFor test expects no error.",
+	)
+	assert.NoError(
+		t,
+		p.SetLogMaskFor(mask.UpTo(pri.Warning), time.Minute),
+		"Posixish SetLogMaskFor test expects no error when"+
+			" replacing the temporary mask.",
+	)
+
+	assert.IsType(
+		t,
+		&Multiline{},
+		p.state().l,
+		"Posixish SetLogMaskFor test expects the mask not to wrap"+
+			" the Posixish syslogger.",
+	)
+
+	assert.Equal(
+		t,
+		mask.UpTo(pri.Warning),
+		p.LogMask(),
+		"Posixish SetLogMaskFor test expects the temporary mask.",
+	)
+
+	expire()
+
+	assert.Equal(
+		t,
+		mask.Mask(0xFF),
+		p.LogMask(),
+		"Posixish SetLogMaskFor test expects the original mask.",
+	)
+
+	actual, e := ioutil.ReadFile(f.Name())
+	require.NoError(t, e)
+	assert.Regexp(
+		t,
+		`^<133>[^\n]+ timed: Log mask temporarily changed from`+
+			` LOG_UPTO\(LOG_DEBUG\) to LOG_UPTO\(LOG_ERR\) for`+
+			` 1m0s\.\n`+
+			`<133>[^\n]+ timed: Log mask temporarily changed from`+
+			` LOG_UPTO\(LOG_ERR\) to LOG_UPTO\(LOG_WARNING\) for`+
+			` 1m0s\.\n`+
+			`<133>[^\n]+ timed: Log mask reverted from`+
+			` LOG_UPTO\(LOG_WARNING\) to`+
+			` LOG_UPTO\(LOG_DEBUG\)\.\n$`,
+		string(actual),
+		"Posixish SetLogMaskFor test expects notices of the changes.",
+	)
+}
+
+func TestPosixishDelayedOpenKeepsMask(t *testing.T) {
+	origNewNativeSyslog := posixishNewNativeSyslog
+	defer func() {
+		posixishNewNativeSyslog = origNewNativeSyslog
+	}()
+	posixishNewNativeSyslog = func(
+		pri.Priority,
+		string,
+	) (*NativeSyslog, error) {
+		return nil, errors.New("Artificial error for NewNativeSyslog")
+	}
+
+	f, e := ioutil.TempFile("", "posixish")
+	require.NoError(
+		t,
+		e,
+		"Posixish delayed open test requires a temporary file.",
+	)
+	origOsStderr := posixishOsStderr
+	defer func() {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		posixishOsStderr = origOsStderr
+	}()
+	posixishOsStderr = f
+
+	p := new(Posixish)
+	require.NoError(t, p.Openlog("delayed", opt.ODelay, pri.Local0))
+	p.SetLogMask(mask.UpTo(pri.Err))
+
+	for _, m := range []string{"first", "second", "third"} {
+		assert.NoError(
+			t,
+			p.Syslog(pri.Info, m+" masked"),
+			"Posixish delayed open test expects no error.",
+		)
+		assert.NoError(
+			t,
+			p.Syslog(pri.Err, m+" unmasked"),
+			"Posixish delayed open test expects no error.",
+		)
+	}
+
+	actual, e := ioutil.ReadFile(f.Name())
+	require.NoError(t, e)
+	assert.Regexp(
+		t,
+		`^<131>[^\n]+ delayed: first unmasked\n`+
+			`<131>[^\n]+ delayed: second unmasked\n`+
+			`<131>[^\n]+ delayed: third unmasked\n$`,
+		string(actual),
+		"Posixish delayed open test expects the mask to remain after"+
+			" the deferred open.",
+	)
+}
+
+// benchmarkPosixish gives a Posixish which writes each message to the null
+// device, so that the benchmarks measure the whole pipeline other than syslogd.
+func benchmarkPosixish(b *testing.B) (*Posixish, func()) {
+	origNewNativeSyslog := posixishNewNativeSyslog
+	origOsStderr := posixishOsStderr
+	origIsTerminal := osIsTerminal
+
+	f, e := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if e != nil {
+		b.Fatal(e)
+	}
+
+	posixishNewNativeSyslog = func(
+		pri.Priority,
+		string,
+	) (*NativeSyslog, error) {
+		return nil, errors.New("Artificial error for NewNativeSyslog")
+	}
+	posixishOsStderr = f
+	osIsTerminal = func(*os.File) bool {
+		return false
+	}
+
+	p := new(Posixish)
+	if e := p.Openlog("benchmark", opt.ODelay, pri.User); e != nil {
+		b.Fatal(e)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	return p, func() {
+		_ = f.Close()
+		posixishNewNativeSyslog = origNewNativeSyslog
+		posixishOsStderr = origOsStderr
+		osIsTerminal = origIsTerminal
+	}
+}
+
+func BenchmarkPosixishSyslog(b *testing.B) {
+	p, done := benchmarkPosixish(b)
+	defer done()
+
+	for i := 0; i < b.N; i++ {
+		_ = p.Syslog(pri.Info, "benchmark")
+	}
+}
+
+func BenchmarkPosixishSyslogParallel(b *testing.B) {
+	p, done := benchmarkPosixish(b)
+	defer done()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = p.Syslog(pri.Info, "benchmark")
+		}
+	})
+}
@@ -0,0 +1,115 @@
+package syslogger
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/proidiot/gone/errors"
+	"github.com/proidiot/gone/log/pri"
+)
+
+const (
+	// DefaultRetryAttempts is the number of attempts made by a Retry which
+	// has not been given a number of attempts.
+	DefaultRetryAttempts = 3
+
+	// DefaultRetryBackoff is the delay before the first retry made by a
+	// Retry which has not been given a backoff.
+	DefaultRetryBackoff = 10 * time.Millisecond
+
+	// DefaultRetryMaxBackoff is the longest delay between retries made by a
+	// Retry which has not been given a maximum backoff.
+	DefaultRetryMaxBackoff = time.Second
+)
+
+// Retry is a syslogger.Syslogger that makes several attempts to log a message
+// to another syslogger.Syslogger before giving up. The delay between attempts
+// doubles after each failure (up to a maximum), and each delay is jittered so
+// that many processes which lost their collector at the same moment don't
+// retry in lockstep.
+type Retry struct {
+	Syslogger Syslogger
+
+	// Attempts is the total number of attempts to make, and zero means
+	// DefaultRetryAttempts.
+	Attempts int
+
+	// Backoff is the nominal delay before the first retry, and zero means
+	// DefaultRetryBackoff.
+	Backoff time.Duration
+
+	// MaxBackoff is the longest nominal delay between attempts, and zero
+	// means DefaultRetryMaxBackoff.
+	MaxBackoff time.Duration
+
+	co closeOnce
+}
+
+// Syslog logs a message. In the case of Retry, the message is passed to another
+// syslogger.Syslogger until it succeeds or the attempts are exhausted, in which
+// case the last error is returned.
+func (r *Retry) Syslog(p pri.Priority, msg interface{}) error {
+	if r.Syslogger == nil {
+		return errors.New(
+			"A syslogger.Retry must have a non-nil syslogger in" +
+				" order to be meaningful, but an attempt has" +
+				" been made to write a log to a" +
+				" syslogger.Retry with a nil syslogger.",
+		)
+	}
+
+	attempts := r.Attempts
+	if attempts <= 0 {
+		attempts = DefaultRetryAttempts
+	}
+
+	backoff := r.Backoff
+	if backoff <= 0 {
+		backoff = DefaultRetryBackoff
+	}
+
+	maxBackoff := r.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = DefaultRetryMaxBackoff
+	}
+
+	var err error
+	for i := 0; i < attempts; i++ {
+		if i != 0 {
+			timeSleep(jitter(backoff))
+
+			backoff *= 2
+			if backoff > maxBackoff {
+				backoff = maxBackoff
+			}
+		}
+
+		if err = r.Syslogger.Syslog(p, msg); err == nil {
+			return nil
+		}
+	}
+
+	return err
+}
+
+// jitter gives a random duration between half of d and d.
+func jitter(d time.Duration) time.Duration {
+	if half := d / 2; half > 0 {
+		return half + time.Duration(rand.Int63n(int64(half)+1))
+	}
+
+	return d
+}
+
+// Flush flushes the other syslogger.Syslogger.
+func (r *Retry) Flush() error {
+	return flushAll(r.Syslogger)
+}
+
+// Close closes the other syslogger.Syslogger, only the first time it is
+// called.
+func (r *Retry) Close() error {
+	return r.co.close(func() error {
+		return closeAll(r.Syslogger)
+	})
+}
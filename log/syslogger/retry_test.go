@@ -0,0 +1,148 @@
+package syslogger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/proidiot/gone/log/pri"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetrySyslog(t *testing.T) {
+	origTimeSleep := timeSleep
+	defer func() {
+		timeSleep = origTimeSleep
+	}()
+
+	type testCase struct {
+		inputNilSyslogger bool
+		inputFailures     int
+		inputAttempts     int
+		inputBackoff      time.Duration
+		inputMaxBackoff   time.Duration
+		expectedError     bool
+		expectedCalls     int
+		expectedBackoffs  []time.Duration
+	}
+
+	tests := map[string]testCase{
+		"nil values": {
+			inputNilSyslogger: true,
+			expectedError:     true,
+		},
+		"first try": {
+			expectedCalls:    1,
+			expectedBackoffs: []time.Duration{},
+		},
+		"default attempts": {
+			inputFailures: 5,
+			expectedError: true,
+			expectedCalls: DefaultRetryAttempts,
+			expectedBackoffs: []time.Duration{
+				DefaultRetryBackoff,
+				2 * DefaultRetryBackoff,
+			},
+		},
+		"eventual success": {
+			inputFailures:   3,
+			inputAttempts:   5,
+			inputBackoff:    time.Second,
+			inputMaxBackoff: time.Minute,
+			expectedCalls:   4,
+			expectedBackoffs: []time.Duration{
+				time.Second,
+				2 * time.Second,
+				4 * time.Second,
+			},
+		},
+		"capped backoff": {
+			inputFailures:   10,
+			inputAttempts:   5,
+			inputBackoff:    time.Second,
+			inputMaxBackoff: 3 * time.Second,
+			expectedError:   true,
+			expectedCalls:   5,
+			expectedBackoffs: []time.Duration{
+				time.Second,
+				2 * time.Second,
+				3 * time.Second,
+				3 * time.Second,
+			},
+		},
+	}
+
+	for explanation, test := range tests {
+		actualBackoffs := []time.Duration{}
+		timeSleep = func(d time.Duration) {
+			actualBackoffs = append(actualBackoffs, d)
+		}
+
+		f := &failingSyslogger{Failures: test.inputFailures}
+
+		r := &Retry{
+			Syslogger:  f,
+			Attempts:   test.inputAttempts,
+			Backoff:    test.inputBackoff,
+			MaxBackoff: test.inputMaxBackoff,
+		}
+		if test.inputNilSyslogger {
+			r.Syslogger = nil
+		}
+
+		actualError := r.Syslog(pri.Info, "retry message")
+
+		if test.expectedError {
+			assert.Errorf(
+				t,
+				actualError,
+				"Retry test expects an error for: %s",
+				explanation,
+			)
+		} else {
+			assert.NoError(
+				t,
+				actualError,
+				"Retry test expects no error for: %s",
+				explanation,
+			)
+		}
+
+		assert.Equal(
+			t,
+			test.expectedCalls,
+			f.Calls,
+			"Retry test expects a specific number of attempts"+
+				" for: %s",
+			explanation,
+		)
+
+		if test.expectedBackoffs != nil {
+			assert.Len(
+				t,
+				actualBackoffs,
+				len(test.expectedBackoffs),
+				"Retry test expects a specific number of"+
+					" backoffs for: %s",
+				explanation,
+			)
+		}
+
+		for i, actual := range actualBackoffs {
+			if i >= len(test.expectedBackoffs) {
+				break
+			}
+
+			nominal := test.expectedBackoffs[i]
+			assert.True(
+				t,
+				actual >= nominal/2 && actual <= nominal,
+				"Retry test expects backoff %d to be jittered"+
+					" below %s, but it was %s for: %s",
+				i,
+				nominal,
+				actual,
+				explanation,
+			)
+		}
+	}
+}
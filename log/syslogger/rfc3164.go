@@ -3,35 +3,95 @@ package syslogger
 import (
 	"fmt"
 	"os"
-	"strings"
+	"strconv"
 	"time"
+	"unicode/utf8"
 
 	"github.com/proidiot/gone/errors"
 	"github.com/proidiot/gone/log/pri"
 )
 
+// Rfc3164MaxLength is the maximum total length in bytes of a syslog message as
+// specified by RFC 3164. It is the frame size used by Rfc3164 unless a
+// different MaxLength has been given.
+const Rfc3164MaxLength = 1024
+
+// Rfc3164TruncationMarker is appended to the content of a message that has been
+// truncated by an Rfc3164 using the OverflowTruncate policy.
+const Rfc3164TruncationMarker = "..."
+
+// Overflow is a policy describing how a formatting syslogger.Syslogger should
+// handle a message that would exceed its maximum frame length.
+type Overflow byte
+
+const (
+	// OverflowError causes an error to be returned instead of logging a
+	// message that is too long. This is the default policy.
+	OverflowError Overflow = iota
+
+	// OverflowTruncate shortens the content of a message that is too long
+	// so that it fits in a single frame, marking the cut with
+	// Rfc3164TruncationMarker. The content is never cut in the middle of
+	// a UTF-8 encoded character.
+	OverflowTruncate
+
+	// OverflowSplit breaks the content of a message that is too long into
+	// several frames, each carrying the same header and a "[i/n] " counter
+	// at the start of the content. The content is never split in the
+	// middle of a UTF-8 encoded character.
+	OverflowSplit
+)
+
 // Rfc3164 is a syslogger.Syslogger that will format the message in a way that
 // is intended to be compliant with RFC 3164 before passing the modified message
-// to another syslogger.Syslogger.
+// to another syslogger.Syslogger. The formatted message is given as a []byte
+// which is only valid until the other syslogger.Syslogger returns.
 type Rfc3164 struct {
 	Syslogger Syslogger
 	Ident     string
 	Facility  pri.Priority
 	Pid       bool
+
+	// Caller puts the source file, line, and function of the code which
+	// logged each message at the start of the content. It must not be used
+	// behind a NoWait, which logs from a different goroutine.
+	Caller bool
+
+	// MaxLength is the maximum total length in bytes of a single frame.
+	// Transports which accept larger datagrams may raise it, and a value
+	// of zero means Rfc3164MaxLength.
+	MaxLength int
+
+	// Overflow is the policy applied to messages whose frame would exceed
+	// MaxLength.
+	Overflow Overflow
+
+	hc headerCache
+
+	co closeOnce
 }
 
 // Syslog logs a message. In the case of Rfc3164, the message is will be given a
 // specific format and then forwarded to another syslogger.Syslogger.
 func (r *Rfc3164) Syslog(p pri.Priority, msg interface{}) error {
-	content, goodType := msg.(string)
-	if !goodType {
+	var content string
+	switch msg := msg.(type) {
+	case string:
+		content = msg
+	case []byte:
+		content = string(msg)
+	default:
 		return errors.New(
 			"The syslogger.Rfc3164 expects the message argument" +
-				" to be a string, but the given message does" +
-				" not have the string type.",
+				" to be a string or []byte, but the given" +
+				" message has a different type.",
 		)
 	}
 
+	if r.Caller {
+		content = callerPrefix() + content
+	}
+
 	if p.ValidFacility() != nil || p.Facility() == 0x00 {
 		if r.Facility == 0x00 {
 			p = pri.User | p.Severity()
@@ -40,14 +100,65 @@ func (r *Rfc3164) Syslog(p pri.Priority, msg interface{}) error {
 		}
 	}
 
-	timestamp := time.Now().Format(time.Stamp)
+	buf := getBuffer()
+	defer putBuffer(buf)
 
-	var hostname string
-	fullHostname, e := osHostname()
-	if e != nil {
-		hostname = "localhost"
+	now := timeNow()
+	b := append((*buf)[:0], '<')
+	b = strconv.AppendUint(b, uint64(p), 10)
+	b = append(b, '>')
+	b = now.AppendFormat(b, time.Stamp)
+	b = append(b, ' ')
+	b = append(b, r.header(now)...)
+	header := len(b)
+
+	max := r.MaxLength
+	if max <= 0 {
+		max = Rfc3164MaxLength
+	}
+
+	if l := header + len(content); l <= max {
+		return forward(r.Syslogger, 0x00, buf, append(b, content...))
+	} else if r.Overflow == OverflowTruncate {
+		room := max - header - len(Rfc3164TruncationMarker)
+		if room < 0 {
+			return r.headerTooLong(header, max)
+		}
+
+		b = append(b, utf8Prefix(content, room)...)
+		b = append(b, Rfc3164TruncationMarker...)
+		return forward(r.Syslogger, 0x00, buf, b)
+	} else if r.Overflow == OverflowSplit {
+		chunks, counter := splitUtf8(content, max-header)
+		if chunks == nil {
+			return r.headerTooLong(header, max)
+		}
+
+		for i, c := range chunks {
+			b = fmt.Appendf(b[:header], counter, i+1, len(chunks))
+			b = append(b, c...)
+			if e := forward(r.Syslogger, 0x00, buf, b); e != nil {
+				return e
+			}
+		}
+
+		return nil
 	} else {
-		hostname = strings.SplitN(fullHostname, ".", 2)[0]
+		return fmt.Errorf(
+			"The maximum total length of an RFC3164 syslog"+
+				" message is %d bytes, but the generated"+
+				" syslog message has total length %d bytes.",
+			max,
+			l,
+		)
+	}
+}
+
+// header gives the part of the header which follows the timestamp, which is
+// only built again when the hostname is due to be looked up again.
+func (r *Rfc3164) header(now time.Time) []byte {
+	if h := r.hc.load(now, r.Ident, r.Pid); h != nil {
+		return h
 	}
 
 	tag := r.Ident
@@ -55,32 +166,79 @@ func (r *Rfc3164) Syslog(p pri.Priority, msg interface{}) error {
 		tag = os.Args[0]
 	}
 
-	pid := ""
+	h := []byte(hostname(true) + " " + tag)
 	if r.Pid {
-		pid = fmt.Sprintf(
-			"[%d]",
-			os.Getpid(),
-		)
+		h = fmt.Appendf(h, "[%d]", os.Getpid())
 	}
+	h = append(h, ": "...)
 
-	res := fmt.Sprintf(
-		"<%d>%s %s %s%s: %s",
-		p,
-		timestamp,
-		hostname,
-		tag,
-		pid,
-		content,
+	r.hc.store(now, r.Ident, r.Pid, h)
+	return h
+}
+
+func (r *Rfc3164) headerTooLong(l int, max int) error {
+	return fmt.Errorf(
+		"The syslogger.Rfc3164 must fit at least some content in"+
+			" a frame of at most %d bytes, but the generated"+
+			" header alone has length %d bytes.",
+		max,
+		l,
 	)
+}
 
-	if l := len([]byte(res)); l > 1024 {
-		return fmt.Errorf(
-			"The maximum total length of an RFC3164 syslog"+
-				" message is 1024 bytes, but the generated"+
-				" syslog message has total length %d bytes.",
-			l,
-		)
+// utf8Prefix gives the longest prefix of s which is at most n bytes long and
+// which does not end in the middle of a UTF-8 encoded character. If there is
+// no character boundary close enough to n (as in content which isn't valid
+// UTF-8), the prefix is simply n bytes long, since the invalid bytes can't be
+// split any worse.
+func utf8Prefix(s string, n int) string {
+	if len(s) <= n {
+		return s
 	}
 
-	return r.Syslogger.Syslog(pri.Priority(0x0), res)
+	for i := n; i >= 0 && i > n-utf8.UTFMax; i-- {
+		if utf8.RuneStart(s[i]) {
+			return s[:i]
+		}
+	}
+
+	return s[:n]
+}
+
+// splitUtf8 breaks s into chunks which each fit in room bytes once prefixed by
+// the returned counter format (which expects the chunk number and the total
+// number of chunks). A nil slice is given if room is too small to make any
+// progress.
+func splitUtf8(s string, room int) ([]string, string) {
+	for digits, limit := 1, 10; ; digits, limit = digits+1, limit*10 {
+		counter := fmt.Sprintf("[%%0%dd/%%d] ", digits)
+		n := room - len(fmt.Sprintf(counter, 0, limit-1))
+		if n < utf8.UTFMax {
+			return nil, ""
+		}
+
+		var chunks []string
+		for rest := s; rest != ""; {
+			c := utf8Prefix(rest, n)
+			chunks = append(chunks, c)
+			rest = rest[len(c):]
+		}
+
+		if len(chunks) < limit {
+			return chunks, counter
+		}
+	}
+}
+
+// Flush flushes the other syslogger.Syslogger.
+func (r *Rfc3164) Flush() error {
+	return flushAll(r.Syslogger)
+}
+
+// Close closes the other syslogger.Syslogger, only the first time it is
+// called.
+func (r *Rfc3164) Close() error {
+	return r.co.close(func() error {
+		return closeAll(r.Syslogger)
+	})
 }
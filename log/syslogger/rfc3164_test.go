@@ -1,8 +1,12 @@
 package syslogger
 
 import (
+	"fmt"
+	"io/ioutil"
 	"regexp"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/proidiot/gone/errors"
 	"github.com/proidiot/gone/log/pri"
@@ -181,3 +185,261 @@ func TestRfc3164Syslog(t *testing.T) {
 		osHostname = origOsHostname
 	}
 }
+
+func TestRfc3164Overflow(t *testing.T) {
+	type testCase struct {
+		inputMaxLength   int
+		inputOverflow    Overflow
+		inputMsg         string
+		expectedError    bool
+		expectedContents []string
+	}
+
+	splitMany := []string{}
+	for i := 1; i <= 12; i++ {
+		c := fmt.Sprintf("[%02d/12] ", i) + strings.Repeat("x", 12)
+		splitMany = append(splitMany, c)
+	}
+
+	tests := map[string]testCase{
+		"fits": {
+			inputMaxLength:   55,
+			inputOverflow:    OverflowSplit,
+			inputMsg:         "short",
+			expectedContents: []string{"short"},
+		},
+		"error": {
+			inputMaxLength: 55,
+			inputMsg:       strings.Repeat("x", 64),
+			expectedError:  true,
+		},
+		"default max length": {
+			inputMsg:         strings.Repeat("x", 900),
+			expectedContents: []string{strings.Repeat("x", 900)},
+		},
+		"raised max length": {
+			inputMaxLength:   4096,
+			inputMsg:         strings.Repeat("x", 2048),
+			expectedContents: []string{strings.Repeat("x", 2048)},
+		},
+		"truncate": {
+			inputMaxLength: 55,
+			inputOverflow:  OverflowTruncate,
+			inputMsg:       strings.Repeat("x", 64),
+			expectedContents: []string{
+				strings.Repeat("x", 17) +
+					Rfc3164TruncationMarker,
+			},
+		},
+		"truncate on rune boundary": {
+			inputMaxLength: 55,
+			inputOverflow:  OverflowTruncate,
+			inputMsg:       strings.Repeat("x", 16) + "étude",
+			expectedContents: []string{
+				strings.Repeat("x", 16) +
+					Rfc3164TruncationMarker,
+			},
+		},
+		"truncate header too long": {
+			inputMaxLength: 30,
+			inputOverflow:  OverflowTruncate,
+			inputMsg:       "x",
+			expectedError:  true,
+		},
+		"split": {
+			inputMaxLength: 55,
+			inputOverflow:  OverflowSplit,
+			inputMsg:       strings.Repeat("x", 28),
+			expectedContents: []string{
+				"[1/2] " + strings.Repeat("x", 14),
+				"[2/2] " + strings.Repeat("x", 14),
+			},
+		},
+		"split on rune boundary": {
+			inputMaxLength: 55,
+			inputOverflow:  OverflowSplit,
+			inputMsg:       strings.Repeat("x", 13) + "éééé",
+			expectedContents: []string{
+				"[1/2] " + strings.Repeat("x", 13),
+				"[2/2] éééé",
+			},
+		},
+		"split invalid UTF-8": {
+			inputMaxLength: 55,
+			inputOverflow:  OverflowSplit,
+			inputMsg:       strings.Repeat("\x80", 28),
+			expectedContents: []string{
+				"[1/2] " + strings.Repeat("\x80", 14),
+				"[2/2] " + strings.Repeat("\x80", 14),
+			},
+		},
+		"truncate invalid UTF-8": {
+			inputMaxLength: 55,
+			inputOverflow:  OverflowTruncate,
+			inputMsg:       strings.Repeat("\x80", 64),
+			expectedContents: []string{
+				strings.Repeat("\x80", 17) +
+					Rfc3164TruncationMarker,
+			},
+		},
+		"split many": {
+			inputMaxLength:   55,
+			inputOverflow:    OverflowSplit,
+			inputMsg:         strings.Repeat("x", 144),
+			expectedContents: splitMany,
+		},
+		"split header too long": {
+			inputMaxLength: 40,
+			inputOverflow:  OverflowSplit,
+			inputMsg:       strings.Repeat("x", 20),
+			expectedError:  true,
+		},
+	}
+
+	origOsHostname := osHostname
+	defer func() {
+		osHostname = origOsHostname
+	}()
+	osHostname = func() (string, error) {
+		return "host", nil
+	}
+
+	for explanation, test := range tests {
+		ra := &recordAllSyslogger{}
+
+		r := &Rfc3164{
+			Syslogger: ra,
+			Ident:     "overflow",
+			MaxLength: test.inputMaxLength,
+			Overflow:  test.inputOverflow,
+		}
+
+		actualError := r.Syslog(pri.Info, test.inputMsg)
+
+		if test.expectedError {
+			assert.Errorf(
+				t,
+				actualError,
+				"Rfc3164 overflow test expects an error"+
+					" for: %s",
+				explanation,
+			)
+		} else {
+			assert.NoError(
+				t,
+				actualError,
+				"Rfc3164 overflow test expects no error"+
+					" for: %s",
+				explanation,
+			)
+		}
+
+		// The header is "<14>Jan _2 15:04:05 host overflow: ", which is
+		// always 35 bytes long.
+		actualContents := []string{}
+		for _, m := range ra.M {
+			assert.True(
+				t,
+				len(m) <= test.inputMaxLength ||
+					test.inputMaxLength == 0,
+				"Rfc3164 overflow test expects frames to fit"+
+					" for: %s",
+				explanation,
+			)
+			actualContents = append(actualContents, m[35:])
+		}
+
+		if test.expectedContents == nil {
+			test.expectedContents = []string{}
+		}
+		assert.Equal(
+			t,
+			test.expectedContents,
+			actualContents,
+			"Rfc3164 overflow test recorded the wrong contents"+
+				" for: %s",
+			explanation,
+		)
+	}
+}
+
+func TestRfc3164HostnameRefresh(t *testing.T) {
+	origOsHostname := osHostname
+	origTimeNow := timeNow
+	defer func() {
+		osHostname = origOsHostname
+		timeNow = origTimeNow
+	}()
+
+	lookups := 0
+	osHostname = func() (string, error) {
+		lookups++
+		return fmt.Sprintf("host%d.example.com", lookups), nil
+	}
+
+	now := time.Date(2006, time.January, 2, 15, 4, 5, 0, time.UTC)
+	timeNow = func() time.Time {
+		return now
+	}
+
+	ra := &recordAllSyslogger{}
+	r := &Rfc3164{
+		Syslogger: ra,
+		Ident:     "refresh",
+	}
+
+	for _, d := range []time.Duration{0, time.Second, hostnameRefresh} {
+		now = now.Add(d)
+		assert.NoError(
+			t,
+			r.Syslog(pri.Info, "message"),
+			"Rfc3164 hostname refresh test expects no error.",
+		)
+	}
+
+	assert.Equal(
+		t,
+		[]string{
+			"<14>Jan  2 15:04:05 host1 refresh: message",
+			"<14>Jan  2 15:04:06 host1 refresh: message",
+			"<14>Jan  2 15:05:06 host2 refresh: message",
+		},
+		ra.M,
+		"Rfc3164 hostname refresh test expects the hostname to be"+
+			" looked up again once it is stale.",
+	)
+}
+
+func TestRfc3164Allocs(t *testing.T) {
+	r := &Rfc3164{
+		Syslogger: &Writer{Writer: ioutil.Discard},
+		Ident:     "allocs",
+		Pid:       true,
+	}
+
+	// The only allocation left is for passing the formatted []byte as an
+	// interface{} to the other syslogger.Syslogger.
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = r.Syslog(pri.Info, "message")
+	})
+	assert.True(
+		t,
+		allocs <= 1,
+		"Rfc3164 allocation test expects at most 1 allocation per"+
+			" message, but there were %v.",
+		allocs,
+	)
+}
+
+func BenchmarkRfc3164Syslog(b *testing.B) {
+	r := &Rfc3164{
+		Syslogger: &Writer{Writer: ioutil.Discard},
+		Ident:     "benchmark",
+		Pid:       true,
+	}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = r.Syslog(pri.Info, "benchmark")
+	}
+}
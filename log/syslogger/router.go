@@ -0,0 +1,164 @@
+package syslogger
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/proidiot/gone/log/mask"
+	"github.com/proidiot/gone/log/pri"
+)
+
+// Route is a rule used by a Router to decide whether a message should be sent
+// to a particular syslogger.Syslogger. A message matches a Route only if it
+// satisfies every condition which has been set.
+type Route struct {
+	Syslogger Syslogger
+
+	// Facilities restricts the Route to messages with one of the given
+	// facilities. An empty list matches every facility.
+	Facilities []pri.Priority
+
+	// Severities restricts the Route to messages with a severity that isn't
+	// masked, so mask.UpTo(pri.Err) matches pri.Err and above. A zero
+	// mask.Mask matches every severity.
+	Severities mask.Mask
+
+	// Idents restricts the Route to Routers with one of the given Idents,
+	// which allows a single set of Routes to be shared by several programs.
+	// An empty list matches every Ident.
+	Idents []string
+
+	// Pattern restricts the Route to messages with content matching the
+	// regular expression. A nil Pattern matches every message.
+	Pattern *regexp.Regexp
+
+	// Final prevents any later Routes from being considered for a message
+	// which matches this Route.
+	Final bool
+}
+
+// Router is a syslogger.Syslogger that dispatches each message to the other
+// syslogger.Sysloggers of every Route the message matches, in order. Messages
+// without a facility are matched as if they had the Router Facility (or
+// pri.User if the Router has none), but they are passed along unaltered. A
+// message which matches no Route is sent to the Default syslogger.Syslogger,
+// or else dropped if there is no Default.
+type Router struct {
+	Routes   []Route
+	Default  Syslogger
+	Ident    string
+	Facility pri.Priority
+
+	co closeOnce
+}
+
+// Syslog logs a message. In the case of Router, the message is sent to each of
+// the other syslogger.Sysloggers selected by the Routes. An attempt is made to
+// send the message to each of them even if one produces an error, in which
+// case the first error is returned.
+func (r *Router) Syslog(p pri.Priority, msg interface{}) error {
+	f := effectiveFacility(p, r.Facility)
+
+	var err error
+	matched := false
+	for _, rt := range r.Routes {
+		if !rt.matches(f, p.Severity(), r.Ident, msg) {
+			continue
+		}
+
+		matched = true
+		if rt.Syslogger != nil {
+			e := rt.Syslogger.Syslog(p, msg)
+			if e != nil && err == nil {
+				err = e
+			}
+		}
+
+		if rt.Final {
+			break
+		}
+	}
+
+	if !matched && r.Default != nil {
+		return r.Default.Syslog(p, msg)
+	}
+
+	return err
+}
+
+func (rt *Route) matches(
+	f pri.Priority,
+	s pri.Priority,
+	ident string,
+	msg interface{},
+) bool {
+	if len(rt.Facilities) != 0 {
+		found := false
+		for _, rf := range rt.Facilities {
+			found = found || rf.Facility() == f
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	if rt.Severities != 0 && rt.Severities.Masked(s) {
+		return false
+	}
+
+	if len(rt.Idents) != 0 {
+		found := false
+		for _, ri := range rt.Idents {
+			found = found || ri == ident
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	if rt.Pattern != nil {
+		var content string
+		switch msg := msg.(type) {
+		case string:
+			content = msg
+		case []byte:
+			content = string(msg)
+		case fmt.Stringer:
+			content = msg.String()
+		case error:
+			content = msg.Error()
+		default:
+			return false
+		}
+
+		if !rt.Pattern.MatchString(content) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Flush flushes the other syslogger.Sysloggers of the Routes and the Default.
+func (r *Router) Flush() error {
+	return flushAll(r.sysloggers()...)
+}
+
+// Close closes the other syslogger.Sysloggers of the Routes and the Default,
+// only the first time it is called.
+func (r *Router) Close() error {
+	return r.co.close(func() error {
+		return closeAll(r.sysloggers()...)
+	})
+}
+
+func (r *Router) sysloggers() []Syslogger {
+	ss := make([]Syslogger, 0, len(r.Routes)+1)
+	for _, rt := range r.Routes {
+		ss = append(ss, rt.Syslogger)
+	}
+
+	return append(ss, r.Default)
+}
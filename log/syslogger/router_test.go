@@ -0,0 +1,202 @@
+package syslogger
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/proidiot/gone/errors"
+	"github.com/proidiot/gone/log/mask"
+	"github.com/proidiot/gone/log/pri"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouterSyslog(t *testing.T) {
+	type testCase struct {
+		inputIdent       string
+		inputFacility    pri.Priority
+		inputPriority    pri.Priority
+		inputMsg         interface{}
+		inputFailSecure  bool
+		expectedError    bool
+		expectedSecure   bool
+		expectedPaging   bool
+		expectedFiles    bool
+		expectedAudit    bool
+		expectedFallback bool
+	}
+
+	tests := map[string]testCase{
+		"nil values": {
+			inputMsg:       nil,
+			expectedPaging: true,
+		},
+		"auth": {
+			inputPriority:  pri.Auth | pri.Info,
+			inputMsg:       "login",
+			expectedSecure: true,
+		},
+		"authpriv error": {
+			inputPriority:  pri.Authpriv | pri.Err,
+			inputMsg:       "bad password",
+			expectedSecure: true,
+			expectedPaging: true,
+		},
+		"secure failure": {
+			inputPriority:   pri.Auth | pri.Crit,
+			inputMsg:        "lockout",
+			inputFailSecure: true,
+			expectedError:   true,
+			expectedPaging:  true,
+		},
+		"error": {
+			inputPriority:  pri.Local0 | pri.Err,
+			inputMsg:       errors.New("disk full"),
+			expectedPaging: true,
+			expectedFiles:  true,
+		},
+		"default facility": {
+			inputFacility: pri.Local0,
+			inputPriority: pri.Notice,
+			inputMsg:      "files",
+			expectedFiles: true,
+		},
+		"notice": {
+			inputPriority:    pri.Notice,
+			inputMsg:         "notice",
+			expectedFallback: true,
+		},
+		"default facility error": {
+			inputFacility:  pri.Local0,
+			inputPriority:  pri.Alert,
+			inputMsg:       "files",
+			expectedFiles:  true,
+			expectedPaging: true,
+		},
+		"pattern": {
+			inputPriority: pri.Info,
+			inputMsg:      &stringer{"AUDIT: user added"},
+			expectedAudit: true,
+		},
+		"pattern, wrong ident": {
+			inputIdent:       "other",
+			inputPriority:    pri.Info,
+			inputMsg:         &stringer{"AUDIT: user added"},
+			expectedFallback: true,
+		},
+		"pattern, non-text message": {
+			inputPriority:    pri.Info,
+			inputMsg:         17,
+			expectedFallback: true,
+		},
+		"unmatched": {
+			inputPriority:    pri.Mail | pri.Debug,
+			inputMsg:         "mail",
+			expectedFallback: true,
+		},
+	}
+
+	for explanation, test := range tests {
+		secure := &switchSyslogger{Fail: test.inputFailSecure}
+		paging := &flagSyslogger{}
+		files := &flagSyslogger{}
+		audit := &flagSyslogger{}
+		fallback := &flagSyslogger{}
+
+		ident := test.inputIdent
+		if ident == "" {
+			ident = "router"
+		}
+
+		r := &Router{
+			Routes: []Route{
+				{
+					Syslogger: secure,
+					Facilities: []pri.Priority{
+						pri.Auth,
+						pri.Authpriv,
+					},
+				},
+				{
+					Syslogger:  paging,
+					Severities: mask.UpTo(pri.Err),
+				},
+				{
+					Syslogger:  files,
+					Facilities: []pri.Priority{pri.Local0},
+					Severities: mask.UpTo(pri.Notice),
+					Final:      true,
+				},
+				{
+					Syslogger: audit,
+					Idents:    []string{"router"},
+					Pattern: regexp.MustCompile(
+						`^AUDIT:`,
+					),
+				},
+				{
+					// Skipped after the final files route.
+					Syslogger: fallback,
+					Severities: mask.UpTo(pri.Notice) &^
+						mask.UpTo(pri.Err),
+				},
+			},
+			Default:  fallback,
+			Ident:    ident,
+			Facility: test.inputFacility,
+		}
+
+		actualError := r.Syslog(test.inputPriority, test.inputMsg)
+
+		if test.expectedError {
+			assert.Errorf(
+				t,
+				actualError,
+				"Router test expects an error for: %s",
+				explanation,
+			)
+		} else {
+			assert.NoError(
+				t,
+				actualError,
+				"Router test expects no error for: %s",
+				explanation,
+			)
+		}
+
+		assert.Equal(
+			t,
+			test.expectedSecure,
+			len(secure.M) != 0,
+			"Router test expects a specific secure call for: %s",
+			explanation,
+		)
+		assert.Equal(
+			t,
+			test.expectedPaging,
+			paging.Flag,
+			"Router test expects a specific paging call for: %s",
+			explanation,
+		)
+		assert.Equal(
+			t,
+			test.expectedFiles,
+			files.Flag,
+			"Router test expects a specific files call for: %s",
+			explanation,
+		)
+		assert.Equal(
+			t,
+			test.expectedAudit,
+			audit.Flag,
+			"Router test expects a specific audit call for: %s",
+			explanation,
+		)
+		assert.Equal(
+			t,
+			test.expectedFallback,
+			fallback.Flag,
+			"Router test expects a specific default call for: %s",
+			explanation,
+		)
+	}
+}
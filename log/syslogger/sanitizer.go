@@ -0,0 +1,106 @@
+package syslogger
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/proidiot/gone/errors"
+	"github.com/proidiot/gone/log/pri"
+)
+
+// Utf8Bom is the byte order mark which RFC 5424 uses to indicate that the
+// content of a message is encoded in UTF-8.
+const Utf8Bom = "\xEF\xBB\xBF"
+
+// Sanitizer is a syslogger.Syslogger that makes a message safe to display
+// before passing it to another syslogger.Syslogger. Control characters (other
+// than horizontal tab) are replaced with octal escape sequences in the style
+// of rsyslog (so an embedded carriage return becomes #015 and an ANSI escape
+// sequence begins with #033), and invalid UTF-8 is replaced with the Unicode
+// replacement character. This prevents user-controlled input from forging
+// fake log lines or otherwise manipulating a terminal.
+type Sanitizer struct {
+	Syslogger Syslogger
+
+	// Bom causes Utf8Bom to be added to the start of each message, as is
+	// recommended by RFC 5424.
+	Bom bool
+
+	co closeOnce
+}
+
+// Syslog logs a message. In the case of Sanitizer, the message is sanitized
+// and then forwarded to another syslogger.Syslogger.
+func (s *Sanitizer) Syslog(p pri.Priority, msg interface{}) error {
+	m, ok := messageText(msg)
+	if !ok {
+		return errors.New(
+			"The *syslogger.Sanitizer expects the message" +
+				" argument to have the type string, []byte," +
+				" fmt.Stringer, or error, but the given" +
+				" message argument does not have one of" +
+				" these types.",
+		)
+	}
+
+	m = sanitize(m)
+	if s.Bom {
+		m = Utf8Bom + m
+	}
+
+	return s.Syslogger.Syslog(p, m)
+}
+
+func sanitary(r rune, size int) bool {
+	switch {
+	case r == utf8.RuneError && size == 1:
+		return false
+	case r == '\t':
+		return true
+	default:
+		return r >= 0x20 && r != 0x7F
+	}
+}
+
+func sanitize(s string) string {
+	clean := true
+	for i := 0; i < len(s) && clean; {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		clean = sanitary(r, size)
+		i += size
+	}
+
+	if clean {
+		return s
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+
+		if sanitary(r, size) {
+			b.WriteRune(r)
+		} else if size == 1 && r != utf8.RuneError {
+			fmt.Fprintf(&b, "#%03o", r)
+		} else {
+			b.WriteRune(utf8.RuneError)
+		}
+	}
+
+	return b.String()
+}
+
+// Flush flushes the other syslogger.Syslogger.
+func (s *Sanitizer) Flush() error {
+	return flushAll(s.Syslogger)
+}
+
+// Close closes the other syslogger.Syslogger, only the first time it is
+// called.
+func (s *Sanitizer) Close() error {
+	return s.co.close(func() error {
+		return closeAll(s.Syslogger)
+	})
+}
@@ -0,0 +1,93 @@
+package syslogger
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/proidiot/gone/errors"
+	"github.com/proidiot/gone/log/pri"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanitizerSyslog(tt *testing.T) {
+	tests := map[string]struct {
+		inputBom       bool
+		inputMsg       interface{}
+		expectedError  bool
+		expectedOutput string
+	}{
+		"zero values": {
+			inputMsg:      nil,
+			expectedError: true,
+		},
+		"bytes": {
+			inputMsg:       []byte("testing\r"),
+			expectedOutput: "testing#015",
+		},
+		"wrapped error": {
+			inputMsg: fmt.Errorf("outer: %w", errors.New("inner")),
+			expectedOutput: "outer: inner#012\t*fmt.wrapError:" +
+				" outer#012\terrors.New: inner",
+		},
+		"clean": {
+			inputMsg:       "testing\tdonné",
+			expectedOutput: "testing\tdonné",
+		},
+		"forged line": {
+			inputMsg: "ok\r\n<13>Jan  1 00:00:00 host su:" +
+				" root login",
+			expectedOutput: "ok#015#012<13>Jan  1 00:00:00 host" +
+				" su: root login",
+		},
+		"ansi escape": {
+			inputMsg:       "\x1b[2Jgone",
+			expectedOutput: "#033[2Jgone",
+		},
+		"nul and del": {
+			inputMsg:       "a\x00b\x7f",
+			expectedOutput: "a#000b#177",
+		},
+		"invalid utf-8": {
+			inputMsg:       "a\xffb\xc3",
+			expectedOutput: "a�b�",
+		},
+		"replacement character kept": {
+			inputMsg:       "a�b",
+			expectedOutput: "a�b",
+		},
+		"bom": {
+			inputBom:       true,
+			inputMsg:       "testing",
+			expectedOutput: Utf8Bom + "testing",
+		},
+		"stringer": {
+			inputMsg:       &stringer{"a\rb"},
+			expectedOutput: "a#015b",
+		},
+		"error": {
+			inputMsg:       errors.New("a\rb"),
+			expectedOutput: "a#015b",
+		},
+	}
+
+	for explanation, test := range tests {
+		tt.Run(explanation, func(t *testing.T) {
+			rec := new(recordStringSyslogger)
+
+			sanitizer := &Sanitizer{
+				Syslogger: rec,
+				Bom:       test.inputBom,
+			}
+
+			actualError := sanitizer.Syslog(pri.Info, test.inputMsg)
+
+			if test.expectedError {
+				assert.Error(t, actualError)
+			} else {
+				assert.NoError(t, actualError)
+			}
+
+			assert.Equal(t, test.expectedOutput, rec.M)
+		})
+	}
+}
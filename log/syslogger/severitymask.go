@@ -10,6 +10,8 @@ import (
 type SeverityMask struct {
 	Syslogger Syslogger
 	Mask      mask.Mask
+
+	co closeOnce
 }
 
 // Syslog logs a message. In the case of SeverityMask, the message is sent to
@@ -21,3 +23,16 @@ func (s *SeverityMask) Syslog(p pri.Priority, msg interface{}) error {
 
 	return s.Syslogger.Syslog(p, msg)
 }
+
+// Flush flushes the other syslogger.Syslogger.
+func (s *SeverityMask) Flush() error {
+	return flushAll(s.Syslogger)
+}
+
+// Close closes the other syslogger.Syslogger, only the first time it is
+// called.
+func (s *SeverityMask) Close() error {
+	return s.co.close(func() error {
+		return closeAll(s.Syslogger)
+	})
+}
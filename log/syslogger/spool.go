@@ -0,0 +1,427 @@
+package syslogger
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/proidiot/gone/errors"
+	"github.com/proidiot/gone/log/pri"
+)
+
+const (
+	// DefaultSpoolMaxBytes is the total size of the segment files which a
+	// Spool which has not been given a maximum size will keep.
+	DefaultSpoolMaxBytes = 64 << 20
+
+	// DefaultSpoolSegmentBytes is the size at which a Spool which has not
+	// been given a segment size will start a new segment file.
+	DefaultSpoolSegmentBytes = 1 << 20
+
+	// DefaultSpoolProbeInterval is the length of time a Spool which has
+	// not been given a probe interval waits before trying to replay its
+	// spooled messages again.
+	DefaultSpoolProbeInterval = 5 * time.Second
+
+	spoolSuffix = ".spool"
+
+	// Each record is a length and checksum of the payload, followed by the
+	// payload itself: a timestamp, a pri.Priority, and the message.
+	spoolRecordHeader  = 8
+	spoolPayloadHeader = 9
+)
+
+// Spool is a syslogger.Syslogger that persists messages which another
+// syslogger.Syslogger fails to log into a bounded queue of segment files in a
+// directory, and then replays them in order once that syslogger.Syslogger
+// recovers. Since the queue lives on disk, messages which are spooled when a
+// process exits are replayed by the next Spool using the same directory.
+//
+// Replay is attempted before each new message is logged (so that the order of
+// messages is preserved), except within the probe interval after a failure
+// (when new messages are spooled straight away), and whenever Flush is called.
+// A message which has been spooled is considered to have been logged
+// successfully, so Syslog only gives an error if a message could neither be
+// logged nor spooled. A message is passed to the other syslogger.Syslogger as
+// it was given, but only its text (with an error which wraps other errors
+// expanded in the same way as by HumanReadable) can be spooled, so a replayed
+// message is passed as a string. Each record is checksummed, and a segment file
+// is abandoned at the first record which has been corrupted.
+type Spool struct {
+	Syslogger Syslogger
+
+	// Dir is the directory holding the segment files, which will be
+	// created if needed. Only one Spool should use a directory at a time.
+	Dir string
+
+	// MaxBytes is the total size of the segment files at which the oldest
+	// segment files are discarded, and zero means DefaultSpoolMaxBytes.
+	MaxBytes int64
+
+	// MaxAge is the age at which a spooled message is discarded rather
+	// than being replayed, and zero means that messages never expire.
+	MaxAge time.Duration
+
+	// SegmentBytes is the size at which a new segment file is started, and
+	// zero means DefaultSpoolSegmentBytes.
+	SegmentBytes int64
+
+	// ProbeInterval is the length of time after a message could not be
+	// logged during which new messages are spooled without first trying
+	// to replay the spooled ones, and zero means
+	// DefaultSpoolProbeInterval. Flush always tries to replay them.
+	ProbeInterval time.Duration
+
+	segs   []spoolSegment
+	loaded bool
+	probe  time.Time
+	x      sync.Mutex
+
+	co closeOnce
+}
+
+type spoolSegment struct {
+	name string
+	size int64
+}
+
+type spoolRecord struct {
+	t time.Time
+	p pri.Priority
+	m []byte
+}
+
+// Syslog logs a message. In the case of Spool, any previously spooled messages
+// are replayed before the message is passed to another syslogger.Syslogger,
+// and the message is spooled if it can't be logged.
+func (s *Spool) Syslog(p pri.Priority, msg interface{}) error {
+	switch msg.(type) {
+	case string, []byte, fmt.Stringer, error:
+	default:
+		return errors.New(
+			"The *syslogger.Spool expects the message argument to" +
+				" have the type string, []byte, fmt.Stringer," +
+				" or error, but the given message argument" +
+				" does not have one of these types.",
+		)
+	}
+
+	if s.Syslogger == nil || s.Dir == "" {
+		return errors.New(
+			"A syslogger.Spool must have a non-nil syslogger and" +
+				" a directory in order to be meaningful, but" +
+				" an attempt has been made to write a log to" +
+				" a syslogger.Spool without them.",
+		)
+	}
+
+	s.x.Lock()
+	defer s.x.Unlock()
+
+	if e := s.load(); e != nil {
+		return e
+	}
+
+	now := timeNow()
+	if len(s.segs) == 0 || !now.Before(s.probe) {
+		var e error
+		if len(s.segs) != 0 {
+			e = s.replay()
+		}
+
+		if e == nil && s.Syslogger.Syslog(p, msg) == nil {
+			return nil
+		}
+
+		probeInterval := s.ProbeInterval
+		if probeInterval <= 0 {
+			probeInterval = DefaultSpoolProbeInterval
+		}
+		s.probe = now.Add(probeInterval)
+	}
+
+	// Only the text of the message can be spooled.
+	m, _ := messageText(msg)
+	return s.spool(spoolRecord{now, p, []byte(m)})
+}
+
+// Flush replays any spooled messages, giving an error if they could not all be
+// logged, and then flushes the other syslogger.Syslogger.
+func (s *Spool) Flush() error {
+	s.x.Lock()
+	defer s.x.Unlock()
+
+	if e := s.load(); e != nil {
+		return e
+	}
+
+	if e := s.replay(); e != nil {
+		return e
+	}
+
+	return flushAll(s.Syslogger)
+}
+
+// Close closes the other syslogger.Syslogger, only the first time it is
+// called. Any messages which are still spooled are left for the next Spool
+// using the same directory.
+func (s *Spool) Close() error {
+	return s.co.close(func() error {
+		return closeAll(s.Syslogger)
+	})
+}
+
+// load reads the names and sizes of the segment files from the directory the
+// first time it is called (or after the segment files could not be written),
+// after which they are tracked in memory.
+func (s *Spool) load() error {
+	if s.loaded {
+		return nil
+	}
+
+	segs, e := s.segments()
+	if e != nil {
+		return e
+	}
+
+	s.segs = segs
+	s.loaded = true
+	return nil
+}
+
+// segments gives the names and sizes of the segment files in the directory,
+// oldest first.
+func (s *Spool) segments() ([]spoolSegment, error) {
+	fis, e := ioutil.ReadDir(s.Dir)
+	if os.IsNotExist(e) {
+		return nil, nil
+	} else if e != nil {
+		return nil, e
+	}
+
+	var segs []spoolSegment
+	for _, fi := range fis {
+		if !fi.IsDir() && strings.HasSuffix(fi.Name(), spoolSuffix) {
+			segs = append(segs, spoolSegment{fi.Name(), fi.Size()})
+		}
+	}
+
+	sort.Slice(segs, func(i, j int) bool {
+		return segs[i].name < segs[j].name
+	})
+	return segs, nil
+}
+
+func (s *Spool) replay() error {
+	for len(s.segs) != 0 {
+		path := filepath.Join(s.Dir, s.segs[0].name)
+
+		records, e := readSpoolSegment(path)
+		if e != nil {
+			s.loaded = false
+			return e
+		}
+
+		for i, r := range records {
+			if s.MaxAge > 0 && timeNow().Sub(r.t) > s.MaxAge {
+				continue
+			}
+
+			if e := s.Syslogger.Syslog(r.p, string(r.m)); e != nil {
+				// The segment file is left alone if none of
+				// its records were used.
+				if i == 0 {
+					return e
+				}
+
+				if e2 := writeSpoolSegment(
+					path,
+					records[i:],
+				); e2 != nil {
+					s.loaded = false
+					return e2
+				}
+
+				var size int64
+				for _, r := range records[i:] {
+					size += int64(spoolRecordHeader +
+						spoolPayloadHeader + len(r.m))
+				}
+				s.segs[0].size = size
+
+				return e
+			}
+		}
+
+		if e := os.Remove(path); e != nil && !os.IsNotExist(e) {
+			s.loaded = false
+			return e
+		}
+
+		s.segs = s.segs[1:]
+	}
+
+	return nil
+}
+
+func (s *Spool) spool(r spoolRecord) error {
+	segmentBytes := s.SegmentBytes
+	if segmentBytes <= 0 {
+		segmentBytes = DefaultSpoolSegmentBytes
+	}
+
+	var seq uint64
+	if len(s.segs) != 0 {
+		last := s.segs[len(s.segs)-1]
+
+		var e error
+		seq, e = strconv.ParseUint(
+			strings.TrimSuffix(last.name, spoolSuffix),
+			16,
+			64,
+		)
+		if e != nil {
+			return e
+		}
+
+		if last.size >= segmentBytes {
+			seq++
+		}
+	}
+
+	segment := fmt.Sprintf("%016x%s", seq, spoolSuffix)
+	if len(s.segs) == 0 || s.segs[len(s.segs)-1].name != segment {
+		if e := os.MkdirAll(s.Dir, 0700); e != nil {
+			return e
+		}
+
+		s.segs = append(s.segs, spoolSegment{name: segment})
+	}
+
+	f, e := os.OpenFile(
+		filepath.Join(s.Dir, segment),
+		os.O_WRONLY|os.O_APPEND|os.O_CREATE,
+		0600,
+	)
+	if e != nil {
+		s.loaded = false
+		return e
+	}
+
+	b := r.encode()
+	_, e = f.Write(b)
+	if e == nil {
+		e = f.Sync()
+	}
+	if e2 := f.Close(); e == nil {
+		e = e2
+	}
+	if e != nil {
+		s.loaded = false
+		return e
+	}
+
+	s.segs[len(s.segs)-1].size += int64(len(b))
+
+	return s.trim()
+}
+
+// trim discards the oldest segment files (other than the newest one, which was
+// just written) until the spool fits within its maximum size.
+func (s *Spool) trim() error {
+	maxBytes := s.MaxBytes
+	if maxBytes <= 0 {
+		maxBytes = DefaultSpoolMaxBytes
+	}
+
+	var total int64
+	for _, seg := range s.segs {
+		total += seg.size
+	}
+
+	for total > maxBytes && len(s.segs) > 1 {
+		e := os.Remove(filepath.Join(s.Dir, s.segs[0].name))
+		if e != nil && !os.IsNotExist(e) {
+			s.loaded = false
+			return e
+		}
+
+		total -= s.segs[0].size
+		s.segs = s.segs[1:]
+	}
+
+	return nil
+}
+
+func (r spoolRecord) encode() []byte {
+	b := make([]byte, spoolRecordHeader+spoolPayloadHeader+len(r.m))
+	payload := b[spoolRecordHeader:]
+
+	binary.BigEndian.PutUint64(payload, uint64(r.t.UnixNano()))
+	payload[8] = byte(r.p)
+	copy(payload[spoolPayloadHeader:], r.m)
+
+	binary.BigEndian.PutUint32(b, uint32(len(payload)))
+	binary.BigEndian.PutUint32(b[4:], crc32.ChecksumIEEE(payload))
+
+	return b
+}
+
+func readSpoolSegment(path string) ([]spoolRecord, error) {
+	b, e := ioutil.ReadFile(path)
+	if e != nil {
+		return nil, e
+	}
+
+	var records []spoolRecord
+	for len(b) >= spoolRecordHeader {
+		l := int(binary.BigEndian.Uint32(b))
+		sum := binary.BigEndian.Uint32(b[4:])
+		b = b[spoolRecordHeader:]
+
+		if l < spoolPayloadHeader || l > len(b) {
+			break
+		}
+
+		payload := b[:l]
+		b = b[l:]
+
+		if crc32.ChecksumIEEE(payload) != sum {
+			break
+		}
+
+		records = append(records, spoolRecord{
+			t: time.Unix(
+				0,
+				int64(binary.BigEndian.Uint64(payload)),
+			),
+			p: pri.Priority(payload[8]),
+			m: payload[spoolPayloadHeader:],
+		})
+	}
+
+	return records, nil
+}
+
+func writeSpoolSegment(path string, records []spoolRecord) error {
+	var b bytes.Buffer
+	for _, r := range records {
+		b.Write(r.encode())
+	}
+
+	tmp := path + ".tmp"
+	if e := ioutil.WriteFile(tmp, b.Bytes(), 0600); e != nil {
+		return e
+	}
+
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,398 @@
+package syslogger
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/proidiot/gone/errors"
+	"github.com/proidiot/gone/log/pri"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpoolSyslog(t *testing.T) {
+	origTimeNow := timeNow
+	defer func() {
+		timeNow = origTimeNow
+	}()
+
+	type step struct {
+		advance       time.Duration
+		fail          bool
+		restart       bool
+		corrupt       bool
+		flush         bool
+		msg           interface{}
+		expectedError bool
+	}
+
+	type testCase struct {
+		inputMaxBytes      int64
+		inputMaxAge        time.Duration
+		inputSegmentBytes  int64
+		inputProbeInterval time.Duration
+		inputNilSyslogger  bool
+		steps              []step
+		expectedMsgs       []string
+		expectedSegments   int
+	}
+
+	tests := map[string]testCase{
+		"nil values": {
+			inputNilSyslogger: true,
+			steps: []step{
+				{msg: "a", expectedError: true},
+			},
+			expectedMsgs: []string{},
+		},
+		"bad message": {
+			steps: []step{
+				{msg: 17, expectedError: true},
+			},
+			expectedMsgs: []string{},
+		},
+		"healthy": {
+			steps: []step{
+				{msg: "a"},
+				{msg: []byte("b")},
+				{msg: errors.New("c")},
+				{msg: &stringer{"d"}},
+			},
+			expectedMsgs: []string{"a", "b", "c", "d"},
+		},
+		"spooled then replayed": {
+			steps: []step{
+				{msg: "a"},
+				{msg: "b", fail: true},
+				{msg: "c", fail: true},
+				{msg: "d", advance: DefaultSpoolProbeInterval},
+			},
+			expectedMsgs: []string{"a", "b", "c", "d"},
+		},
+		"within probe interval": {
+			steps: []step{
+				{msg: "a", fail: true},
+				{msg: "b", advance: time.Second},
+			},
+			expectedMsgs:     []string{},
+			expectedSegments: 1,
+		},
+		"after probe interval": {
+			steps: []step{
+				{msg: "a", fail: true},
+				{msg: "b", advance: time.Second},
+				{msg: "c", advance: DefaultSpoolProbeInterval},
+			},
+			expectedMsgs: []string{"a", "b", "c"},
+		},
+		"custom probe interval": {
+			inputProbeInterval: time.Minute,
+			steps: []step{
+				{msg: "a", fail: true},
+				{msg: "b", advance: DefaultSpoolProbeInterval},
+				{msg: "c", advance: time.Minute},
+			},
+			expectedMsgs: []string{"a", "b", "c"},
+		},
+		"flush within probe interval": {
+			steps: []step{
+				{msg: "a", fail: true},
+				{flush: true},
+			},
+			expectedMsgs: []string{"a"},
+		},
+		"still spooled": {
+			steps: []step{
+				{msg: "a", fail: true},
+				{msg: "b", fail: true},
+			},
+			expectedMsgs:     []string{},
+			expectedSegments: 1,
+		},
+		"flush": {
+			steps: []step{
+				{msg: "a", fail: true},
+				{flush: true, fail: true, expectedError: true},
+				{flush: true},
+			},
+			expectedMsgs: []string{"a"},
+		},
+		"survives restart": {
+			steps: []step{
+				{msg: "a", fail: true},
+				{msg: "b", fail: true},
+				{restart: true, flush: true},
+			},
+			expectedMsgs: []string{"a", "b"},
+		},
+		"expired": {
+			inputMaxAge: time.Minute,
+			steps: []step{
+				{msg: "a", fail: true},
+				{
+					msg:     "b",
+					fail:    true,
+					advance: 30 * time.Second,
+				},
+				{msg: "c", advance: 45 * time.Second},
+			},
+			expectedMsgs: []string{"b", "c"},
+		},
+		"segments": {
+			inputSegmentBytes: 1,
+			steps: []step{
+				{msg: "a", fail: true},
+				{msg: "b", fail: true},
+				{msg: "c", fail: true},
+			},
+			expectedMsgs:     []string{},
+			expectedSegments: 3,
+		},
+		"trimmed": {
+			inputSegmentBytes: 1,
+			inputMaxBytes:     40,
+			steps: []step{
+				{msg: "a", fail: true},
+				{msg: "b", fail: true},
+				{msg: "c", fail: true},
+				{msg: "d", advance: DefaultSpoolProbeInterval},
+			},
+			expectedMsgs: []string{"b", "c", "d"},
+		},
+		"corrupt": {
+			steps: []step{
+				{msg: "a", fail: true},
+				{msg: "b", fail: true},
+				{
+					corrupt: true,
+					msg:     "c",
+					advance: DefaultSpoolProbeInterval,
+				},
+			},
+			expectedMsgs: []string{"a", "c"},
+		},
+	}
+
+	for explanation, test := range tests {
+		now := time.Date(2001, 2, 3, 4, 5, 6, 0, time.UTC)
+		timeNow = func() time.Time {
+			return now
+		}
+
+		dir, e := ioutil.TempDir("", "spool")
+		require.NoError(t, e, "Spool test requires a temporary dir.")
+
+		sw := &switchSyslogger{}
+		newSpool := func() *Spool {
+			s := &Spool{
+				Syslogger:     sw,
+				Dir:           filepath.Join(dir, "spool"),
+				MaxBytes:      test.inputMaxBytes,
+				MaxAge:        test.inputMaxAge,
+				SegmentBytes:  test.inputSegmentBytes,
+				ProbeInterval: test.inputProbeInterval,
+			}
+			if test.inputNilSyslogger {
+				s.Syslogger = nil
+			}
+			return s
+		}
+		s := newSpool()
+
+		for i, st := range test.steps {
+			now = now.Add(st.advance)
+			sw.Fail = st.fail
+
+			if st.restart {
+				s = newSpool()
+			}
+
+			if st.corrupt {
+				segments, e := s.segments()
+				require.NoError(t, e)
+				path := filepath.Join(s.Dir, segments[0].name)
+				b, e := ioutil.ReadFile(path)
+				require.NoError(t, e)
+				b[len(b)-1] ^= 0xFF
+				e = ioutil.WriteFile(path, b, 0600)
+				require.NoError(t, e)
+			}
+
+			var actualError error
+			if st.flush {
+				actualError = s.Flush()
+			} else {
+				actualError = s.Syslog(pri.Info, st.msg)
+			}
+
+			if st.expectedError {
+				assert.Errorf(
+					t,
+					actualError,
+					"Spool test expects an error at"+
+						" step %d for: %s",
+					i,
+					explanation,
+				)
+			} else {
+				assert.NoError(
+					t,
+					actualError,
+					"Spool test expects no error at"+
+						" step %d for: %s",
+					i,
+					explanation,
+				)
+			}
+		}
+
+		actualMsgs := sw.M
+		if actualMsgs == nil {
+			actualMsgs = []string{}
+		}
+		assert.Equal(
+			t,
+			test.expectedMsgs,
+			actualMsgs,
+			"Spool test expects specific messages for: %s",
+			explanation,
+		)
+
+		for _, p := range sw.P {
+			assert.Equal(
+				t,
+				pri.Info,
+				p,
+				"Spool test expects priorities to be kept"+
+					" for: %s",
+				explanation,
+			)
+		}
+
+		segments, e := s.segments()
+		assert.NoError(t, e)
+		assert.Len(
+			t,
+			segments,
+			test.expectedSegments,
+			"Spool test expects specific segments for: %s",
+			explanation,
+		)
+
+		_ = os.RemoveAll(dir)
+	}
+}
+
+func TestSpoolReplayFailure(t *testing.T) {
+	origTimeNow := timeNow
+	defer func() {
+		timeNow = origTimeNow
+	}()
+
+	now := time.Date(2001, 2, 3, 4, 5, 6, 0, time.UTC)
+	timeNow = func() time.Time {
+		return now
+	}
+
+	dir, e := ioutil.TempDir("", "spool")
+	require.NoError(t, e, "Spool test requires a temporary dir.")
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	sw := &switchSyslogger{Fail: true}
+	s := &Spool{
+		Syslogger: sw,
+		Dir:       dir,
+	}
+
+	require.NoError(t, s.Syslog(pri.Info, "a"))
+	require.NoError(t, s.Syslog(pri.Info, "b"))
+
+	path := filepath.Join(dir, fmt.Sprintf("%016x%s", 0, spoolSuffix))
+	before, e := os.Stat(path)
+	require.NoError(t, e)
+
+	now = now.Add(DefaultSpoolProbeInterval)
+	require.NoError(t, s.Syslog(pri.Info, "c"))
+
+	after, e := os.Stat(path)
+	require.NoError(t, e)
+	assert.True(
+		t,
+		os.SameFile(before, after),
+		"Spool replay failure test expects the segment file not to be"+
+			" rewritten when no record was replayed.",
+	)
+
+	records, e := readSpoolSegment(path)
+	require.NoError(t, e)
+	assert.Len(
+		t,
+		records,
+		3,
+		"Spool replay failure test expects every message to stay"+
+			" spooled.",
+	)
+}
+
+func TestSpoolMessageTypes(t *testing.T) {
+	origTimeNow := timeNow
+	defer func() {
+		timeNow = origTimeNow
+	}()
+
+	now := time.Date(2001, 2, 3, 4, 5, 6, 0, time.UTC)
+	timeNow = func() time.Time {
+		return now
+	}
+
+	dir, e := ioutil.TempDir("", "spool")
+	require.NoError(t, e, "Spool test requires a temporary dir.")
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	sw := &switchSyslogger{}
+	s := &Spool{
+		Syslogger: sw,
+		Dir:       dir,
+	}
+
+	live := fmt.Errorf("live: %w", errors.New("cause"))
+	require.NoError(t, s.Syslog(pri.Info, live))
+
+	sw.Fail = true
+	spooled := fmt.Errorf("spooled: %w", errors.New("cause"))
+	require.NoError(t, s.Syslog(pri.Info, spooled))
+
+	sw.Fail = false
+	now = now.Add(DefaultSpoolProbeInterval)
+	require.NoError(t, s.Syslog(pri.Info, "next"))
+
+	require.Len(t, sw.Raw, 3)
+	assert.Same(
+		t,
+		live,
+		sw.Raw[0],
+		"Spool message type test expects a logged message to be"+
+			" passed along unchanged.",
+	)
+	assert.Equal(
+		t,
+		errorText(spooled),
+		sw.Raw[1],
+		"Spool message type test expects a spooled error to be"+
+			" replayed with its chain expanded.",
+	)
+	assert.Equal(
+		t,
+		"next",
+		sw.Raw[2],
+		"Spool message type test expects messages to stay in order.",
+	)
+}
@@ -0,0 +1,99 @@
+package syslogger
+
+import (
+	"runtime"
+	"strconv"
+
+	"github.com/proidiot/gone/log/mask"
+	"github.com/proidiot/gone/log/pri"
+)
+
+// DefaultStacktraceSeverities is the mask.Mask of severities which a
+// Stacktrace which has not been given any Severities attaches a stack trace
+// to.
+var DefaultStacktraceSeverities = mask.UpTo(pri.Crit)
+
+// Stacktrace is a syslogger.Syslogger that attaches a stack trace of the
+// goroutine which logged a message to the end of the content before passing it
+// to another syslogger.Syslogger, if the message has a severity which isn't
+// masked. Frames in the log package (and its subpackages) and the runtime
+// package are left out, so the trace starts with the code which logged the
+// message. Each frame takes two lines, so the other syslogger.Syslogger should
+// be able to handle multi-line messages (such as with a Multiline).
+//
+// Only messages which are text (a string, []byte, fmt.Stringer, or error) are
+// given a stack trace, and they are always passed on as a string. A Stacktrace
+// must not be used behind a NoWait, which logs from a different goroutine.
+type Stacktrace struct {
+	Syslogger Syslogger
+
+	// Severities is the mask.Mask of severities which are given a stack
+	// trace, and zero means DefaultStacktraceSeverities.
+	Severities mask.Mask
+
+	co closeOnce
+}
+
+// Syslog logs a message. In the case of Stacktrace, the message is given a
+// stack trace if its severity isn't masked, and then sent to another
+// syslogger.Syslogger.
+func (st *Stacktrace) Syslog(p pri.Priority, msg interface{}) error {
+	m := st.Severities
+	if m == 0 {
+		m = DefaultStacktraceSeverities
+	}
+
+	if !m.Masked(p.Severity()) {
+		if s, ok := messageText(msg); ok {
+			msg = s + stacktrace()
+		}
+	}
+
+	return st.Syslogger.Syslog(p, msg)
+}
+
+// stacktrace gives the frames of the current goroutine which aren't skipped by
+// callerFrame, in the form given by appendFrames.
+func stacktrace() string {
+	var pcs [callerDepth]uintptr
+	n := runtime.Callers(2, pcs[:])
+
+	return string(appendFrames(nil, pcs[:n], ""))
+}
+
+// appendFrames appends the frames of the given program counters which aren't
+// skipped by callerFrame, one per pair of lines in the same form as a panic
+// (with each line given the indent), with each pair preceded by a newline.
+func appendFrames(b []byte, pcs []uintptr, indent string) []byte {
+	frames := runtime.CallersFrames(pcs)
+	for {
+		f, more := frames.Next()
+		if f.PC != 0 && !skipFrame(f) {
+			b = append(b, '\n')
+			b = append(b, indent...)
+			b = append(b, f.Function...)
+			b = append(b, "()\n\t"...)
+			b = append(b, indent...)
+			b = append(b, f.File...)
+			b = append(b, ':')
+			b = strconv.AppendInt(b, int64(f.Line), 10)
+		}
+
+		if !more {
+			return b
+		}
+	}
+}
+
+// Flush flushes the other syslogger.Syslogger.
+func (st *Stacktrace) Flush() error {
+	return flushAll(st.Syslogger)
+}
+
+// Close closes the other syslogger.Syslogger, only the first time it is
+// called.
+func (st *Stacktrace) Close() error {
+	return st.co.close(func() error {
+		return closeAll(st.Syslogger)
+	})
+}
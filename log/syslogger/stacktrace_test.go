@@ -0,0 +1,106 @@
+package syslogger
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/proidiot/gone/log/mask"
+	"github.com/proidiot/gone/log/pri"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStacktraceSyslog(t *testing.T) {
+	type testCase struct {
+		inputSeverities mask.Mask
+		inputPri        pri.Priority
+		inputMsg        interface{}
+		expectedTrace   bool
+		expectedMsg     interface{}
+	}
+
+	tests := map[string]testCase{
+		"default severities above threshold": {
+			inputPri:      pri.Crit,
+			inputMsg:      "message",
+			expectedTrace: true,
+		},
+		"default severities below threshold": {
+			inputPri:    pri.Err,
+			inputMsg:    "message",
+			expectedMsg: "message",
+		},
+		"facility is ignored": {
+			inputPri:      pri.Local0 | pri.Emerg,
+			inputMsg:      "message",
+			expectedTrace: true,
+		},
+		"given severities": {
+			inputSeverities: mask.UpTo(pri.Warning),
+			inputPri:        pri.Warning,
+			inputMsg:        []byte("message"),
+			expectedTrace:   true,
+		},
+		"masked by given severities": {
+			inputSeverities: mask.Alert,
+			inputPri:        pri.Emerg,
+			inputMsg:        "message",
+			expectedMsg:     "message",
+		},
+		"error": {
+			inputPri:      pri.Alert,
+			inputMsg:      &errorSysloggerError{E: "failure"},
+			expectedTrace: true,
+		},
+		"unsupported type": {
+			inputPri:    pri.Emerg,
+			inputMsg:    42,
+			expectedMsg: 42,
+		},
+	}
+
+	trace := regexp.MustCompile(
+		`^[^\n]+\n` +
+			`[^\n]+/syslogger\.TestStacktraceSyslog\(\)\n` +
+			`\t[^\n]+/stacktrace_test\.go:\d+\n` +
+			`testing\.tRunner\(\)\n` +
+			`\t[^\n]+/testing\.go:\d+$`,
+	)
+
+	for explanation, test := range tests {
+		ls := &lastSyslogger{}
+		s := &Stacktrace{
+			Syslogger:  ls,
+			Severities: test.inputSeverities,
+		}
+
+		e := s.Syslog(test.inputPri, test.inputMsg)
+		actual := ls.M
+		assert.NoError(
+			t,
+			e,
+			"Stacktrace test expects no error for: %s",
+			explanation,
+		)
+
+		if !test.expectedTrace {
+			assert.Equal(
+				t,
+				test.expectedMsg,
+				actual,
+				"Stacktrace test expects the message unchanged"+
+					" for: %s",
+				explanation,
+			)
+			continue
+		}
+
+		m, _ := actual.(string)
+		assert.Regexp(
+			t,
+			trace,
+			m,
+			"Stacktrace test expects a stack trace for: %s",
+			explanation,
+		)
+	}
+}
@@ -9,3 +9,12 @@ import (
 type Syslogger interface {
 	Syslog(p pri.Priority, msg interface{}) error
 }
+
+// Flusher is implemented by a Syslogger which may hold on to messages for a
+// while (or which passes them to another Syslogger which may), so that they
+// can all be logged before the program exits. Every Syslogger which wraps
+// other Sysloggers is a Flusher and an io.Closer, and passes each call along
+// to the Sysloggers it wraps.
+type Flusher interface {
+	Flush() error
+}
@@ -0,0 +1,22 @@
+//go:build darwin || dragonfly || freebsd || netbsd || openbsd
+// +build darwin dragonfly freebsd netbsd openbsd
+
+package syslogger
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// isTerminal indicates whether the given file refers to a terminal.
+func isTerminal(f *os.File) bool {
+	var t syscall.Termios
+	_, _, e := syscall.Syscall(
+		syscall.SYS_IOCTL,
+		f.Fd(),
+		syscall.TIOCGETA,
+		uintptr(unsafe.Pointer(&t)),
+	)
+	return e == 0
+}
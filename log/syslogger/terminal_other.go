@@ -0,0 +1,15 @@
+//go:build !linux && !darwin && !dragonfly && !freebsd && !netbsd && !openbsd
+// +build !linux,!darwin,!dragonfly,!freebsd,!netbsd,!openbsd
+
+package syslogger
+
+import (
+	"os"
+)
+
+// isTerminal indicates whether the given file refers to a terminal. Terminal
+// detection isn't supported on this platform, so no file is considered to be a
+// terminal.
+func isTerminal(f *os.File) bool {
+	return false
+}
@@ -0,0 +1,165 @@
+package syslogger
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/proidiot/gone/log/mask"
+	"github.com/proidiot/gone/log/pri"
+)
+
+// TimedMask is a syslogger.Syslogger that only forwards messages that aren't
+// masked to another syslogger.Syslogger, much like SeverityMask, except that
+// its mask.Mask can be overridden temporarily with SetLogMaskFor. Once the
+// override expires, the original Mask is used again. A notice is logged to the
+// other syslogger.Syslogger (regardless of either mask.Mask) whenever the
+// override starts or ends, so that the change is visible in the log itself.
+type TimedMask struct {
+	Syslogger Syslogger
+
+	// Mask is the mask.Mask used when there is no override. It should not
+	// be changed directly once the TimedMask is in use; use SetLogMask
+	// instead.
+	Mask mask.Mask
+
+	override mask.Mask
+	active   bool
+	timer    *time.Timer
+	gen      int
+	x        sync.Mutex
+	co       closeOnce
+}
+
+// Syslog logs a message. In the case of TimedMask, the message is sent to
+// another syslogger.Syslogger if and only if the message isn't masked by the
+// current mask.Mask.
+func (t *TimedMask) Syslog(p pri.Priority, msg interface{}) error {
+	if t.LogMask().Masked(p.Severity()) {
+		return nil
+	}
+
+	return t.Syslogger.Syslog(p, msg)
+}
+
+// LogMask gives the mask.Mask currently in effect, which is the override if one
+// is active or else the Mask.
+func (t *TimedMask) LogMask() mask.Mask {
+	t.x.Lock()
+	defer t.x.Unlock()
+
+	if t.active {
+		return t.override
+	}
+
+	return t.Mask
+}
+
+// SetLogMask replaces the Mask with the given mask.Mask, ending any override
+// which is active (without a notice, since the change was made explicitly),
+// and gives the mask.Mask which was in effect before.
+func (t *TimedMask) SetLogMask(m mask.Mask) mask.Mask {
+	t.x.Lock()
+	defer t.x.Unlock()
+
+	prev := t.Mask
+	if t.active {
+		prev = t.override
+		t.active = false
+		t.timer.Stop()
+		t.timer = nil
+	}
+
+	// Any timer which fired before it could be stopped must not log a
+	// notice about reverting to the replaced Mask.
+	t.gen++
+	t.Mask = m
+
+	return prev
+}
+
+// SetLogMaskFor overrides the Mask with the given mask.Mask for the given
+// length of time, replacing any override which is already active. Errors from
+// logging the notices about the override are ignored, since the override
+// applies either way.
+func (t *TimedMask) SetLogMaskFor(m mask.Mask, d time.Duration) error {
+	t.x.Lock()
+
+	prev := t.Mask
+	if t.active {
+		prev = t.override
+		t.timer.Stop()
+	}
+
+	// The generation prevents a timer which fired before it could be
+	// stopped from ending the new override early.
+	t.gen++
+	gen := t.gen
+
+	t.override = m
+	t.active = true
+	t.timer = timeAfterFunc(d, func() {
+		t.expire(gen)
+	})
+
+	t.x.Unlock()
+
+	// Not holding the lock here because the actual Syslog call may take
+	// some time.
+	_ = t.Syslogger.Syslog(
+		pri.Notice,
+		fmt.Sprintf(
+			"Log mask temporarily changed from %s to %s for %s.",
+			prev,
+			m,
+			d,
+		),
+	)
+
+	return nil
+}
+
+func (t *TimedMask) expire(gen int) {
+	t.x.Lock()
+	if t.gen != gen || !t.active {
+		t.x.Unlock()
+		return
+	}
+
+	prev := t.override
+	m := t.Mask
+	t.active = false
+	t.timer = nil
+	t.x.Unlock()
+
+	_ = t.Syslogger.Syslog(
+		pri.Notice,
+		fmt.Sprintf(
+			"Log mask reverted from %s to %s.",
+			prev,
+			m,
+		),
+	)
+}
+
+// Flush flushes the other syslogger.Syslogger.
+func (t *TimedMask) Flush() error {
+	return flushAll(t.Syslogger)
+}
+
+// Close ends any override without a notice and closes the other
+// syslogger.Syslogger, only the first time it is called.
+func (t *TimedMask) Close() error {
+	return t.co.close(func() error {
+		t.x.Lock()
+		if t.active {
+			t.active = false
+			t.timer.Stop()
+			t.timer = nil
+		}
+		t.gen++
+		t.x.Unlock()
+
+		return closeAll(t.Syslogger)
+	})
+}
@@ -0,0 +1,263 @@
+package syslogger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/proidiot/gone/log/mask"
+	"github.com/proidiot/gone/log/pri"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimedMaskSyslog(t *testing.T) {
+	origTimeAfterFunc := timeAfterFunc
+	defer func() {
+		timeAfterFunc = origTimeAfterFunc
+	}()
+
+	var expirations []func()
+	timeAfterFunc = func(d time.Duration, f func()) *time.Timer {
+		expirations = append(expirations, f)
+		return time.NewTimer(time.Hour)
+	}
+
+	type testCase struct {
+		inputMask       mask.Mask
+		inputOverrides  []mask.Mask
+		inputExpire     []int
+		inputPri        pri.Priority
+		expectedMask    mask.Mask
+		expectedCall    bool
+		expectedNotices []string
+	}
+
+	tests := map[string]testCase{
+		"no override": {
+			inputMask:    mask.UpTo(pri.Info),
+			inputPri:     pri.Debug,
+			expectedMask: mask.UpTo(pri.Info),
+			expectedCall: false,
+		},
+		"active override": {
+			inputMask:      mask.UpTo(pri.Info),
+			inputOverrides: []mask.Mask{mask.UpTo(pri.Debug)},
+			inputPri:       pri.Debug,
+			expectedMask:   mask.UpTo(pri.Debug),
+			expectedCall:   true,
+			expectedNotices: []string{
+				"Log mask temporarily changed from" +
+					" LOG_UPTO(LOG_INFO) to" +
+					" LOG_UPTO(LOG_DEBUG) for 1m0s.",
+			},
+		},
+		"expired override": {
+			inputMask:      mask.UpTo(pri.Info),
+			inputOverrides: []mask.Mask{mask.UpTo(pri.Debug)},
+			inputExpire:    []int{0},
+			inputPri:       pri.Debug,
+			expectedMask:   mask.UpTo(pri.Info),
+			expectedCall:   false,
+			expectedNotices: []string{
+				"Log mask temporarily changed from" +
+					" LOG_UPTO(LOG_INFO) to" +
+					" LOG_UPTO(LOG_DEBUG) for 1m0s.",
+				"Log mask reverted from LOG_UPTO(LOG_DEBUG)" +
+					" to LOG_UPTO(LOG_INFO).",
+			},
+		},
+		"replaced override ignores stale expiry": {
+			inputMask: mask.UpTo(pri.Info),
+			inputOverrides: []mask.Mask{
+				mask.UpTo(pri.Debug),
+				mask.UpTo(pri.Err),
+			},
+			inputExpire:  []int{0},
+			inputPri:     pri.Warning,
+			expectedMask: mask.UpTo(pri.Err),
+			expectedCall: false,
+			expectedNotices: []string{
+				"Log mask temporarily changed from" +
+					" LOG_UPTO(LOG_INFO) to" +
+					" LOG_UPTO(LOG_DEBUG) for 1m0s.",
+				"Log mask temporarily changed from" +
+					" LOG_UPTO(LOG_DEBUG) to" +
+					" LOG_UPTO(LOG_ERR) for 1m0s.",
+			},
+		},
+		"expiry happens once": {
+			inputMask:      mask.UpTo(pri.Info),
+			inputOverrides: []mask.Mask{mask.UpTo(pri.Debug)},
+			inputExpire:    []int{0, 0},
+			inputPri:       pri.Info,
+			expectedMask:   mask.UpTo(pri.Info),
+			expectedCall:   true,
+			expectedNotices: []string{
+				"Log mask temporarily changed from" +
+					" LOG_UPTO(LOG_INFO) to" +
+					" LOG_UPTO(LOG_DEBUG) for 1m0s.",
+				"Log mask reverted from LOG_UPTO(LOG_DEBUG)" +
+					" to LOG_UPTO(LOG_INFO).",
+			},
+		},
+	}
+
+	for explanation, test := range tests {
+		expirations = nil
+		ra := &recordAllSyslogger{}
+
+		tm := &TimedMask{
+			Syslogger: ra,
+			Mask:      test.inputMask,
+		}
+
+		for _, m := range test.inputOverrides {
+			e := tm.SetLogMaskFor(m, time.Minute)
+			assert.NoError(
+				t,
+				e,
+				"TimedMask test expects no error from"+
+					" SetLogMaskFor for: %s",
+				explanation,
+			)
+		}
+
+		for _, i := range test.inputExpire {
+			expirations[i]()
+		}
+
+		assert.Equal(
+			t,
+			test.expectedMask,
+			tm.LogMask(),
+			"TimedMask test expects a different mask for: %s",
+			explanation,
+		)
+
+		notices := len(ra.M)
+		e := tm.Syslog(test.inputPri, "message")
+		assert.NoError(
+			t,
+			e,
+			"TimedMask test expects no error for: %s",
+			explanation,
+		)
+
+		assert.Equal(
+			t,
+			test.expectedCall,
+			len(ra.M) > notices,
+			"TimedMask test call check failure for: %s",
+			explanation,
+		)
+
+		assert.Equal(
+			t,
+			test.expectedNotices,
+			[]string(ra.M[:notices]),
+			"TimedMask test expects different notices for: %s",
+			explanation,
+		)
+	}
+}
+
+func TestTimedMaskSetLogMask(t *testing.T) {
+	origTimeAfterFunc := timeAfterFunc
+	defer func() {
+		timeAfterFunc = origTimeAfterFunc
+	}()
+
+	var expirations []func()
+	timeAfterFunc = func(d time.Duration, f func()) *time.Timer {
+		expirations = append(expirations, f)
+		return time.NewTimer(time.Hour)
+	}
+
+	type testCase struct {
+		inputMask       mask.Mask
+		inputOverride   bool
+		inputSet        mask.Mask
+		inputExpire     bool
+		expectedPrev    mask.Mask
+		expectedMask    mask.Mask
+		expectedNotices int
+	}
+
+	tests := map[string]testCase{
+		"widen": {
+			inputMask:    mask.UpTo(pri.Err),
+			inputSet:     mask.UpTo(pri.Debug),
+			expectedPrev: mask.UpTo(pri.Err),
+			expectedMask: mask.UpTo(pri.Debug),
+		},
+		"narrow": {
+			inputMask:    mask.UpTo(pri.Debug),
+			inputSet:     mask.UpTo(pri.Err),
+			expectedPrev: mask.UpTo(pri.Debug),
+			expectedMask: mask.UpTo(pri.Err),
+		},
+		"ends override": {
+			inputMask:       mask.UpTo(pri.Err),
+			inputOverride:   true,
+			inputSet:        mask.UpTo(pri.Warning),
+			expectedPrev:    mask.UpTo(pri.Debug),
+			expectedMask:    mask.UpTo(pri.Warning),
+			expectedNotices: 1,
+		},
+		"stale expiry after set": {
+			inputMask:       mask.UpTo(pri.Err),
+			inputOverride:   true,
+			inputSet:        mask.UpTo(pri.Warning),
+			inputExpire:     true,
+			expectedPrev:    mask.UpTo(pri.Debug),
+			expectedMask:    mask.UpTo(pri.Warning),
+			expectedNotices: 1,
+		},
+	}
+
+	for explanation, test := range tests {
+		expirations = nil
+		ra := &recordAllSyslogger{}
+
+		tm := &TimedMask{
+			Syslogger: ra,
+			Mask:      test.inputMask,
+		}
+
+		if test.inputOverride {
+			_ = tm.SetLogMaskFor(mask.UpTo(pri.Debug), time.Minute)
+		}
+
+		prev := tm.SetLogMask(test.inputSet)
+
+		if test.inputExpire {
+			expirations[0]()
+		}
+
+		assert.Equal(
+			t,
+			test.expectedPrev,
+			prev,
+			"TimedMask SetLogMask test expects a different"+
+				" previous mask for: %s",
+			explanation,
+		)
+
+		assert.Equal(
+			t,
+			test.expectedMask,
+			tm.LogMask(),
+			"TimedMask SetLogMask test expects a different mask"+
+				" for: %s",
+			explanation,
+		)
+
+		assert.Len(
+			t,
+			ra.M,
+			test.expectedNotices,
+			"TimedMask SetLogMask test expects a different number"+
+				" of notices for: %s",
+			explanation,
+		)
+	}
+}
@@ -11,6 +11,13 @@ import (
 // io.Writer.
 type Writer struct {
 	Writer io.Writer
+
+	// Closer is closed by Close when it is non-nil, and is meant to be set
+	// to the io.Writer only when the Writer owns it (such as a file which
+	// was opened just for the Writer).
+	Closer io.Closer
+
+	co closeOnce
 }
 
 // Syslog logs a message. In the case of Writer, the message is written directly
@@ -40,3 +47,26 @@ func (w *Writer) Syslog(p pri.Priority, msg interface{}) error {
 		)
 	}
 }
+
+// Flush flushes the io.Writer if it has a Flush function (as a bufio.Writer
+// does). The io.Writer is never closed by Flush.
+func (w *Writer) Flush() error {
+	if f, ok := w.Writer.(interface{ Flush() error }); ok {
+		return f.Flush()
+	}
+
+	return nil
+}
+
+// Close closes the Closer of the Writer if it has one, only the first time it
+// is called. The io.Writer itself is otherwise never closed, since it may be
+// shared (such as os.Stderr).
+func (w *Writer) Close() error {
+	return w.co.close(func() error {
+		if w.Closer == nil {
+			return nil
+		}
+
+		return w.Closer.Close()
+	})
+}